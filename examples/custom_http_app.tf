@@ -38,9 +38,9 @@ resource "eaa_application" "jira-app" {
     }
 
     advanced_settings {
-        is_ssl_verification_enabled = "false"
-        ignore_cname_resolution = "true"
-        g2o_enabled = "true"
+        is_ssl_verification_enabled = false
+        ignore_cname_resolution = true
+        g2o_enabled = true
 	}
 
     auth_enabled = "true"
@@ -42,10 +42,10 @@ resource "eaa_application" "tfapp5" {
   }
 
   advanced_settings {
-      is_ssl_verification_enabled = "false"
-      ignore_cname_resolution = "true"
-      g2o_enabled = "true"
-      edge_authentication_enabled = "true"
+      is_ssl_verification_enabled = false
+      ignore_cname_resolution = true
+      g2o_enabled = true
+      edge_authentication_enabled = true
 	}
 
   popregion = "us-east-1"
@@ -96,10 +96,10 @@ resource "eaa_application" "tfapp7" {
   }
 
   advanced_settings {
-      is_ssl_verification_enabled = "false"
-      ignore_cname_resolution = "true"
-      g2o_enabled = "true"
-      edge_authentication_enabled = "true"
+      is_ssl_verification_enabled = false
+      ignore_cname_resolution = true
+      g2o_enabled = true
+      edge_authentication_enabled = true
 	}
 
   popregion = "us-east-1"
@@ -0,0 +1,57 @@
+terraform {
+    required_providers {
+        eaa = {
+            source  = "terraform.eaaprovider.dev/eaaprovider/eaa"
+            version = "1.0.0"
+        }
+    }
+}
+
+provider "eaa" {
+    contractid       = "1-3XXXXX"
+    edgerc           = ".edgerc"
+}
+
+resource "eaa_application" "grafana" {
+    provider    = eaa
+
+    app_type    = "saas"
+
+    name        = "Grafana"
+    description = "Grafana OIDC app created using terraform"
+
+    oidc {
+        redirect_uris = ["https://grafana.example.com/login/generic_oauth"]
+        scopes        = ["openid", "profile", "email"]
+
+        claim {
+            name  = "email"
+            scope = "email"
+            src   = "user.email"
+        }
+
+        claim {
+            name = "groups"
+            src  = "user.groups"
+        }
+    }
+
+    auth_enabled = "true"
+
+    app_authentication {
+        app_idp = "employees-idp"
+    }
+}
+
+output "grafana_oidc_client_id" {
+    value = eaa_application.grafana.oidc[0].client_id
+}
+
+output "grafana_oidc_client_secret" {
+    value     = eaa_application.grafana.oidc[0].client_secret
+    sensitive = true
+}
+
+output "grafana_oidc_discovery_url" {
+    value = eaa_application.grafana.oidc[0].discovery_url
+}
@@ -0,0 +1,50 @@
+terraform {
+    required_providers {
+        eaa = {
+            source  = "terraform.eaaprovider.dev/eaaprovider/eaa"
+            version = "1.0.0"
+        }
+    }
+}
+
+provider "eaa" {
+    contractid       = "1-3XXXXX"
+    edgerc           = ".edgerc"
+}
+
+resource "eaa_application" "salesforce" {
+    provider    = eaa
+
+    app_type    = "saas"
+
+    name        = "Salesforce"
+    description = "Salesforce SAML app created using terraform"
+
+    saml {
+        sp {
+            entity_id = "https://example.my.salesforce.com"
+            acs_url   = "https://example.my.salesforce.com?so=00D000000000001"
+            req_bind  = "post"
+        }
+
+        subject {
+            fmt = "email"
+            src = "user.email"
+        }
+
+        attribute {
+            name = "firstName"
+            src  = "user.firstName"
+        }
+    }
+
+    auth_enabled = "true"
+
+    app_authentication {
+        app_idp = "employees-idp"
+    }
+}
+
+output "salesforce_idp_metadata" {
+    value = eaa_application.salesforce.saml[0].idp_metadata
+}
@@ -36,12 +36,12 @@ resource "eaa_application" "sql-lab-dc1-app" {
     agents = ["EAA_DC1_US1_TCP_01"]
 
     advanced_settings {
-        is_ssl_verification_enabled = "false"
-        ignore_cname_resolution = "true"
-        g2o_enabled = "true"
-        ip_access_allow = "false"
-        x_wapp_read_timeout = "300"
-        internal_host_port = "300"
+        is_ssl_verification_enabled = false
+        ignore_cname_resolution = true
+        g2o_enabled = true
+        ip_access_allow = false
+        x_wapp_read_timeout = 300
+        internal_host_port = 300
         internal_hostname = "myhost999.com"
 	  }
 
@@ -1,9 +1,9 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 )
 
 var (
@@ -64,34 +64,30 @@ type ConnectorResponse struct {
 	Connectors []Connector `json:"objects,omitempty"`
 }
 
-func GetAgents(ec *EaaClient) ([]Connector, error) {
-	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, AGENTS_URL)
-	agentsResponse := ConnectorResponse{}
+// GetAgents returns the connectors of the tenant, served from the lookup cache when possible
+func GetAgents(ctx context.Context, ec *EaaClient) ([]Connector, error) {
+	return cachedLookup(ctx, ec, CACHE_KEY_AGENTS, func() ([]Connector, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, AGENTS_URL)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &agentsResponse, false)
-	if err != nil {
-		return nil, err
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		updErrMsg := fmt.Errorf("%w: %s", ErrAgentsGet, desc)
-
-		return nil, updErrMsg
-	}
+		connectors, err := ListAll[Connector](ctx, ec, apiURL, false, ErrAgentsGet)
+		if err != nil {
+			return nil, err
+		}
 
-	var agents []Connector
-	for _, conn := range agentsResponse.Connectors {
-		if conn.Name == "" || conn.UUIDURL == "" {
-			continue
+		var agents []Connector
+		for _, conn := range connectors {
+			if conn.Name == "" || conn.UUIDURL == "" {
+				continue
+			}
+			agents = append(agents, conn)
 		}
-		agents = append(agents, conn)
-	}
 
-	return agents, nil
+		return agents, nil
+	})
 }
 
-func GetAgentUUIDs(ec *EaaClient, agentNames []string) ([]string, error) {
-	agents, err := GetAgents(ec)
+func GetAgentUUIDs(ctx context.Context, ec *EaaClient, agentNames []string) ([]string, error) {
+	agents, err := GetAgents(ctx, ec)
 	if err != nil {
 		return nil, ErrAgentsGet
 	}
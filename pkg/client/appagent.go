@@ -41,7 +41,7 @@ type AssignAgentsRequest struct {
 func (aar *AssignAgents) AssignAgents(ctx context.Context, ec *EaaClient) error {
 	ec.Logger.Info("AssignAgents")
 	var agents AssignAgentsRequest
-	agentUUIDs, err := GetAgentUUIDs(ec, aar.AgentNames)
+	agentUUIDs, err := GetAgentUUIDs(ctx, ec, aar.AgentNames)
 	if err != nil {
 		ec.Logger.Error("unable to lookup uuids from agent names")
 		return err
@@ -51,7 +51,7 @@ func (aar *AssignAgents) AssignAgents(ctx context.Context, ec *EaaClient) error
 			UUIDURL: uuid,
 		}
 		agents.Agents = append(agents.Agents, agent)
-		ec.Logger.Info(uuid)
+		ec.Logger.Debug("connector", "uuid", uuid)
 	}
 
 	if len(agents.Agents) == 0 {
@@ -60,33 +60,30 @@ func (aar *AssignAgents) AssignAgents(ctx context.Context, ec *EaaClient) error
 	}
 
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/agents", URL_SCHEME, ec.Host, APPS_URL, aar.AppId)
-	ec.Logger.Info(apiURL)
-	agentsResp, err := ec.SendAPIRequest(apiURL, "POST", agents, nil, false)
+	agentsResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", agents, nil, false)
 	if err != nil {
 		ec.Logger.Error("assign agents failed StatusCode: ", agentsResp.StatusCode)
 		return err
 	}
 	if !(agentsResp.StatusCode >= http.StatusOK && agentsResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(agentsResp)
-		assignErrMsg := fmt.Errorf("%w: %s", ErrAgentsAssign, desc)
-		ec.Logger.Error("assign agents failed StatusCode: desc: ", agentsResp.StatusCode, desc)
+		assignErrMsg := NewAPIError(agentsResp, ErrAgentsAssign)
+		ec.Logger.Error("assign agents failed", "status", agentsResp.StatusCode, "error", assignErrMsg)
 		return assignErrMsg
 	}
 	return nil
 }
 
-func (app *Application) GetAppAgents(ec *EaaClient) ([]string, error) {
+func (app *Application) GetAppAgents(ctx context.Context, ec *EaaClient) ([]string, error) {
 	ec.Logger.Info("GetAppAgents")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/agents", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 	agentsResponse := AppAgentResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &agentsResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &agentsResponse, false)
 	if err != nil {
 		return nil, err
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		updErrMsg := fmt.Errorf("%w: %s", ErrAgentsGet, desc)
+		updErrMsg := NewAPIError(getResp, ErrAgentsGet)
 
 		return nil, updErrMsg
 	}
@@ -107,14 +104,14 @@ type UnAssignAgentsRequest struct {
 func (aar *AssignAgents) UnAssignAgents(ctx context.Context, ec *EaaClient) error {
 	ec.Logger.Info("UnAssignAgents")
 	var agents UnAssignAgentsRequest
-	agentUUIDs, err := GetAgentUUIDs(ec, aar.AgentNames)
+	agentUUIDs, err := GetAgentUUIDs(ctx, ec, aar.AgentNames)
 	if err != nil {
 		ec.Logger.Error("unable to lookup uuids from agent names")
 		return err
 	}
 	for _, uuid := range agentUUIDs {
 		agents.Agents = append(agents.Agents, uuid)
-		ec.Logger.Info(uuid)
+		ec.Logger.Debug("connector", "uuid", uuid)
 	}
 	if len(agents.Agents) == 0 {
 		ec.Logger.Error("no connectors to unassign")
@@ -122,16 +119,14 @@ func (aar *AssignAgents) UnAssignAgents(ctx context.Context, ec *EaaClient) erro
 	}
 
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/agents?method=delete", URL_SCHEME, ec.Host, APPS_URL, aar.AppId)
-	ec.Logger.Info(apiURL)
-	agentsResp, err := ec.SendAPIRequest(apiURL, "POST", agents, nil, false)
+	agentsResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", agents, nil, false)
 	if err != nil {
 		ec.Logger.Error("unassign agents failed StatusCode: ", agentsResp.StatusCode)
 		return err
 	}
 	if !(agentsResp.StatusCode >= http.StatusOK && agentsResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(agentsResp)
-		assignErrMsg := fmt.Errorf("%w: %s", ErrAgentsUnAssign, desc)
-		ec.Logger.Error("unassign agents failed StatusCode: desc: ", agentsResp.StatusCode, desc)
+		assignErrMsg := NewAPIError(agentsResp, ErrAgentsUnAssign)
+		ec.Logger.Error("unassign agents failed", "status", agentsResp.StatusCode, "error", assignErrMsg)
 		return assignErrMsg
 	}
 	return nil
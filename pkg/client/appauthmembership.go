@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -34,18 +35,17 @@ type AppIdpMembershipResponse struct {
 	AppIdpMemberships []AppIdpMembership `json:"objects"`
 }
 
-func (app *Application) GetAppIdpMembership(ec *EaaClient) (*AppIdpMembership, error) {
+func (app *Application) GetAppIdpMembership(ctx context.Context, ec *EaaClient) (*AppIdpMembership, error) {
 	ec.Logger.Info("get App-Idp membership")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/idp_membership", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 	appidpMembershipResponse := AppIdpMembershipResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &appidpMembershipResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &appidpMembershipResponse, false)
 	if err != nil {
 		return nil, err
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appIdpErrMsg := fmt.Errorf("%w: %s", ErrAppIdpMembershipGet, desc)
+		appIdpErrMsg := NewAPIError(getResp, ErrAppIdpMembershipGet)
 		return nil, appIdpErrMsg
 	}
 	if len(appidpMembershipResponse.AppIdpMemberships) > 0 {
@@ -75,18 +75,17 @@ type AppDirectoryMembershipResponse struct {
 	AppDirectoryMemberships []AppDirectoryMembership `json:"objects"`
 }
 
-func (app *Application) GetAppDirectoryMembership(ec *EaaClient) ([]AppDirectoryMembership, error) {
+func (app *Application) GetAppDirectoryMembership(ctx context.Context, ec *EaaClient) ([]AppDirectoryMembership, error) {
 	ec.Logger.Info("get App-Directory membership")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/directories_membership", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 	appdirectoryMembershipResponse := AppDirectoryMembershipResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &appdirectoryMembershipResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &appdirectoryMembershipResponse, false)
 	if err != nil {
 		return nil, err
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appDirErrMsg := fmt.Errorf("%w: %s", ErrAppDirectoryMembershipGet, desc)
+		appDirErrMsg := NewAPIError(getResp, ErrAppDirectoryMembershipGet)
 		return nil, appDirErrMsg
 	}
 	if len(appdirectoryMembershipResponse.AppDirectoryMemberships) >= 0 {
@@ -114,18 +113,17 @@ type AppGroupMembershipResponse struct {
 	AppGroupMemberships []AppGroupMembership `json:"objects"`
 }
 
-func (app *Application) GetAppGroupMembership(ec *EaaClient) ([]AppGroupMembership, error) {
+func (app *Application) GetAppGroupMembership(ctx context.Context, ec *EaaClient) ([]AppGroupMembership, error) {
 	ec.Logger.Info("get App-Group membership")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/groups", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 	appgroupMembershipResponse := AppGroupMembershipResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &appgroupMembershipResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &appgroupMembershipResponse, false)
 	if err != nil {
 		return nil, err
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appGrpErrMsg := fmt.Errorf("%w: %s", ErrAppGroupMembershipGet, desc)
+		appGrpErrMsg := NewAPIError(getResp, ErrAppGroupMembershipGet)
 		return nil, appGrpErrMsg
 	}
 	if len(appgroupMembershipResponse.AppGroupMemberships) >= 0 {
@@ -134,64 +132,49 @@ func (app *Application) GetAppGroupMembership(ec *EaaClient) ([]AppGroupMembersh
 	return nil, ErrAppGroupMembershipGet
 }
 
-func (app *Application) CreateAppAuthenticationStruct(ec *EaaClient) ([]interface{}, error) {
-	ec.Logger.Info("create App Authentication struct")
-	appAuth := make(map[string]interface{})
+// AppAuthentication is the IDP of an application with the directories and groups allowed to access it
+type AppAuthentication struct {
+	IDP         string
+	Directories []DirectoryAssignment
+}
 
-	// Get the data from the auth membership functions
-	appIDPMembership, err := app.GetAppIdpMembership(ec)
+// GetAppAuthentication returns the authentication of the application, nil when no IDP is assigned.
+// Directories are listed in the order the API returns them, with the groups assigned from each of them.
+func (app *Application) GetAppAuthentication(ctx context.Context, ec *EaaClient) (*AppAuthentication, error) {
+	appIDPMembership, err := app.GetAppIdpMembership(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
-
 	if appIDPMembership == nil {
-		return []interface{}{appAuth}, nil
+		return nil, nil
 	}
 
-	appAuth["app_idp"] = appIDPMembership.IDP.Name
-	appDirectoryMemberships, err := app.GetAppDirectoryMembership(ec)
+	appDirectoryMemberships, err := app.GetAppDirectoryMembership(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
-
-	appGroupMemberships, err := app.GetAppGroupMembership(ec)
+	appGroupMemberships, err := app.GetAppGroupMembership(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
 
-	directories := make(map[string]map[string]interface{})
+	appAuth := &AppAuthentication{IDP: appIDPMembership.IDP.Name}
+	index := make(map[string]int)
 	for _, dir := range appDirectoryMemberships {
-		dirName := dir.Directory.Name
-		directories[dirName] = make(map[string]interface{})
-		directories[dirName]["name"] = dirName
+		index[dir.Directory.Name] = len(appAuth.Directories)
+		appAuth.Directories = append(appAuth.Directories, DirectoryAssignment{Name: dir.Directory.Name})
 	}
-
 	for _, group := range appGroupMemberships {
-		dirName := group.Group.DirName
-		groupName := group.Group.GroupName
-
-		if dir, exists := directories[dirName]; exists {
-			// If "app_groups" key is not present, add it before appending groups
-			if _, hasGroups := dir["app_groups"]; !hasGroups {
-				dir["app_groups"] = make([]map[string]interface{}, 0)
-			}
-			groupInfo := make(map[string]interface{})
-			groupInfo["name"] = groupName
-			dir["app_groups"] = append(dir["app_groups"].([]map[string]interface{}), groupInfo)
+		i, ok := index[group.Group.DirName]
+		if !ok {
+			continue
 		}
-	}
-
-	directoriesData := make([]map[string]interface{}, 0)
-	for _, dir := range directories {
-		appGroups, ok := dir["app_groups"].([]map[string]interface{})
-		if ok && len(appGroups) > 0 {
-			directoriesData = append(directoriesData, dir)
+		assignment := GroupAssignment{Name: group.Group.GroupName}
+		if group.EnableMFA != "" {
+			enableMFA := group.EnableMFA
+			assignment.EnableMFA = &enableMFA
 		}
+		appAuth.Directories[i].Groups = append(appAuth.Directories[i].Groups, assignment)
 	}
-	// add "app_directories" key if the list is not empty
-	if len(directoriesData) > 0 {
-		appAuth["app_directories"] = directoriesData
-	}
-	return []interface{}{appAuth}, nil
-
+	return appAuth, nil
 }
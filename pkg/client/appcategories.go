@@ -1,9 +1,9 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 )
 
 var (
@@ -27,35 +27,32 @@ type AppCategoryResponse struct {
 }
 
 // GetAppCategories method retrieves app categories and formats the data as a list of maps
-func GetAppCategories(ec *EaaClient) ([]AppCate, error) {
+// The result is served from the lookup cache when possible
+func GetAppCategories(ctx context.Context, ec *EaaClient) ([]AppCate, error) {
 	ec.Logger.Info("GetAppCategories")
-	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, APP_CATEGORIES_URL)
-	acResponse := AppCategoryResponse{}
+	return cachedLookup(ctx, ec, CACHE_KEY_APPCATEGORIES, func() ([]AppCate, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, APP_CATEGORIES_URL)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &acResponse, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get app categories: %w", err)
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appCatErrMsg := fmt.Errorf("%w: %s", ErrAppCategoriesGet, desc)
-		return nil, appCatErrMsg
-	}
+		appCategories, err := ListAll[AppCate](ctx, ec, apiURL, false, ErrAppCategoriesGet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get app categories: %w", err)
+		}
 
-	var acs []AppCate
-	for _, ac := range acResponse.AppCategories {
-		if ac.Name == "" || ac.UUIDURL == "" {
-			continue
+		var acs []AppCate
+		for _, ac := range appCategories {
+			if ac.Name == "" || ac.UUIDURL == "" {
+				continue
+			}
+			acs = append(acs, ac)
 		}
-		acs = append(acs, ac)
-	}
 
-	return acs, nil
+		return acs, nil
+	})
 }
 
 // GetAppCategoryUuid method fetches categories and then searches for a specific category by name to return its UUID
-func GetAppCategoryUuid(ec *EaaClient, categoryName string) (string, error) {
-	acs, err := GetAppCategories(ec)
+func GetAppCategoryUuid(ctx context.Context, ec *EaaClient, categoryName string) (string, error) {
+	acs, err := GetAppCategories(ctx, ec)
 	if err != nil {
 		return "", ErrAppCategoriesGet
 	}
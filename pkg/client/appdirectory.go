@@ -57,17 +57,15 @@ func (dirData *AppDirectory) AssignIdpDirectory(ctx context.Context, ec *EaaClie
 	}
 
 	apiURL := fmt.Sprintf("%s://%s/%s/appdirectories", URL_SCHEME, ec.Host, MGMT_POP_URL)
-	ec.Logger.Info(apiURL)
 
-	appDirResp, err := ec.SendAPIRequest(apiURL, "POST", result, nil, false)
+	appDirResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", result, nil, false)
 
 	if err != nil {
 		ec.Logger.Error("assign directories to application failed. err", err)
 		return err
 	}
 	if !(appDirResp.StatusCode >= http.StatusOK && appDirResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appDirResp)
-		assignDirErrMsg := fmt.Errorf("%w: %s", ErrAssignDirectoryFailure, desc)
+		assignDirErrMsg := NewAPIError(appDirResp, ErrAssignDirectoryFailure)
 		ec.Logger.Error("assign directories to application failed. appDirResp.StatusCode", appDirResp.StatusCode)
 		return assignDirErrMsg
 	}
@@ -79,7 +77,7 @@ func (dirData *DirectoryData) GetIdpDirectoryGroup(ctx context.Context, ec *EaaC
 	ec.Logger.Info("get IDP Group ")
 	for _, group := range dirData.Groups {
 		if groupName == group.Name {
-			ec.Logger.Info(group.Name)
+			ec.Logger.Debug("group", "name", group.Name)
 			return &group, nil
 		}
 	}
@@ -88,32 +86,23 @@ func (dirData *DirectoryData) GetIdpDirectoryGroup(ctx context.Context, ec *EaaC
 }
 
 // AssignIdpDirectoryGroups assigns IDP directory groups to an application
-func (dirData *DirectoryData) AssignIdpDirectoryGroups(ctx context.Context, ec *EaaClient, app_uuid_url string, appGroupsList []interface{}) error {
+// Groups that do not belong to the directory are skipped
+func (dirData *DirectoryData) AssignIdpDirectoryGroups(ctx context.Context, ec *EaaClient, app_uuid_url string, assignments []GroupAssignment) error {
 	var groups []map[string]interface{}
 
-	for _, s := range appGroupsList {
-		if gData, ok := s.(map[string]interface{}); ok {
-			appgroup := AppGroup{}
-			gn, ok := gData["name"].(string)
-			if !ok || gn == "" {
-				continue
-			}
-			grp, err := dirData.GetIdpDirectoryGroup(ctx, ec, gn)
-			if err != nil {
-				continue
-			}
-			appgroup.UUIDURL = grp.UUID_URL
-
-			if em, ok := gData["enable_mfa"].(string); ok {
-				appgroup.EnableMFA = &em
-			}
-
-			group := map[string]interface{}{
-				"uuid_url":   appgroup.UUIDURL,
-				"enable_mfa": appgroup.EnableMFA,
-			}
-			groups = append(groups, group)
+	for _, assignment := range assignments {
+		if assignment.Name == "" {
+			continue
 		}
+		grp, err := dirData.GetIdpDirectoryGroup(ctx, ec, assignment.Name)
+		if err != nil {
+			continue
+		}
+		group := map[string]interface{}{
+			"uuid_url":   grp.UUID_URL,
+			"enable_mfa": assignment.EnableMFA,
+		}
+		groups = append(groups, group)
 	}
 	if len(groups) == 0 {
 		return nil
@@ -130,17 +119,15 @@ func (dirData *DirectoryData) AssignIdpDirectoryGroups(ctx context.Context, ec *
 	}
 
 	apiURL := fmt.Sprintf("%s://%s/%s/appgroups", URL_SCHEME, ec.Host, MGMT_POP_URL)
-	ec.Logger.Info(apiURL)
 
-	appGroupResp, err := ec.SendAPIRequest(apiURL, "POST", result, nil, false)
+	appGroupResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", result, nil, false)
 
 	if err != nil {
 		ec.Logger.Error("assign groups to application failed. err", err)
 		return err
 	}
 	if !(appGroupResp.StatusCode >= http.StatusOK && appGroupResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appGroupResp)
-		assignGrpErrMsg := fmt.Errorf("%w: %s", ErrAssignGroupFailure, desc)
+		assignGrpErrMsg := NewAPIError(appGroupResp, ErrAssignGroupFailure)
 		ec.Logger.Error("assign groups to application failed. appGroupResp.StatusCode: ", appGroupResp.StatusCode)
 		return assignGrpErrMsg
 	}
@@ -175,17 +162,15 @@ func (dirData *DirectoryData) AssignAllDirectoryGroups(ctx context.Context, ec *
 	}
 
 	apiURL := fmt.Sprintf("%s://%s/%s/appgroups", URL_SCHEME, ec.Host, MGMT_POP_URL)
-	ec.Logger.Info(apiURL)
 
-	appGroupResp, err := ec.SendAPIRequest(apiURL, "POST", result, nil, false)
+	appGroupResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", result, nil, false)
 
 	if err != nil {
 		ec.Logger.Error("assign directory groups to application failed. err", err)
 		return err
 	}
 	if !(appGroupResp.StatusCode >= http.StatusOK && appGroupResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appGroupResp)
-		appGroupErrMsg := fmt.Errorf("%w: %s", ErrAssignGroupFailure, desc)
+		appGroupErrMsg := NewAPIError(appGroupResp, ErrAssignGroupFailure)
 		ec.Logger.Error("assign directory groups to application failed. appGroupResp.StatusCode: ", appGroupResp.StatusCode)
 		return appGroupErrMsg
 	}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -11,7 +12,7 @@ type AppIdp struct {
 }
 
 // AssignIDP method handles the assignment of an IDP to an application.
-func (ai *AppIdp) AssignIDP(ec *EaaClient) error {
+func (ai *AppIdp) AssignIDP(ctx context.Context, ec *EaaClient) error {
 	ec.Logger.Info("assigning IDP to application")
 	if ai.App == "" || ai.IDP == "" {
 		errMsg := fmt.Errorf("%w: app or idp is empty", ErrAssignIdpFailure)
@@ -19,16 +20,14 @@ func (ai *AppIdp) AssignIDP(ec *EaaClient) error {
 		return errMsg
 	}
 	apiURL := fmt.Sprintf("%s://%s/%s/appidp", URL_SCHEME, ec.Host, MGMT_POP_URL)
-	ec.Logger.Info(apiURL)
 
-	appIdpResp, err := ec.SendAPIRequest(apiURL, "POST", ai, nil, false)
+	appIdpResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", ai, nil, false)
 	if err != nil {
 		ec.Logger.Error("assign IDP to Application failed. err", err)
 		return err
 	}
 	if !(appIdpResp.StatusCode >= http.StatusOK && appIdpResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appIdpResp)
-		appIdpErrMsg := fmt.Errorf("%w: %s", ErrAssignIdpFailure, desc)
+		appIdpErrMsg := NewAPIError(appIdpResp, ErrAssignIdpFailure)
 		ec.Logger.Error("assigning IDP to Application failed. appIdpResp.StatusCode", appIdpResp.StatusCode)
 		return appIdpErrMsg
 	}
@@ -40,7 +39,7 @@ type UnAssignIDPRequest struct {
 }
 
 // UnAssignIDP method handles the unassignment of an IDP to an application.
-func (ai *AppIdp) UnAssignIDP(ec *EaaClient) error {
+func (ai *AppIdp) UnAssignIDP(ctx context.Context, ec *EaaClient) error {
 	ec.Logger.Info("unassigning IDP from application")
 	if ai.App == "" || ai.IDP == "" {
 		errMsg := fmt.Errorf("%w: app or idp is empty", ErrAssignIdpFailure)
@@ -50,17 +49,15 @@ func (ai *AppIdp) UnAssignIDP(ec *EaaClient) error {
 	var unassignIdp UnAssignIDPRequest
 
 	apiURL := fmt.Sprintf("%s://%s/%s/appidp?method=DELETE", URL_SCHEME, ec.Host, MGMT_POP_URL)
-	ec.Logger.Info(apiURL)
 	unassignIdp.IDP = append(unassignIdp.IDP, ai.IDP)
 
-	appIdpResp, err := ec.SendAPIRequest(apiURL, "POST", unassignIdp, nil, false)
+	appIdpResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", unassignIdp, nil, false)
 	if err != nil {
 		ec.Logger.Error("unassign IDP to Application failed. err", err)
 		return err
 	}
 	if !(appIdpResp.StatusCode >= http.StatusOK && appIdpResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appIdpResp)
-		appIdpErrMsg := fmt.Errorf("%w: %s", ErrAssignIdpFailure, desc)
+		appIdpErrMsg := NewAPIError(appIdpResp, ErrAssignIdpFailure)
 		ec.Logger.Error("unassigning IDP to Application failed. appIdpResp.StatusCode", appIdpResp.StatusCode)
 		return appIdpErrMsg
 	}
@@ -2,12 +2,10 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+	"time"
 )
 
 type CreateAppRequest struct {
@@ -18,92 +16,11 @@ type CreateAppRequest struct {
 	ClientAppMode int     `json:"client_app_mode"`
 }
 
-func (car *CreateAppRequest) CreateAppRequestFromSchema(ctx context.Context, d *schema.ResourceData, ec *EaaClient) error {
-	logger := ec.Logger
-	if name, ok := d.GetOk("name"); ok {
-		nameStr, ok := name.(string)
-		if ok && nameStr != "" {
-			car.Name = name.(string)
-		}
-	} else {
-		logger.Error("create Application failed. name is invalid")
-		return ErrInvalidValue
-	}
-
-	if description, ok := d.GetOk("description"); ok {
-		descriptionStr, ok := description.(string)
-		if ok && descriptionStr != "" {
-			car.Description = &descriptionStr
-		}
-	}
-
-	if appType, ok := d.GetOk("app_type"); ok {
-		strAppType, ok := appType.(string)
-		if !ok {
-			logger.Error("create Application failed. app_type is invalid")
-			return ErrInvalidType
-		}
-		atype := ClientAppType(strAppType)
-		value, err := atype.ToInt()
-		if err != nil {
-			logger.Error("create Application failed. app_type is invalid")
-			return ErrInvalidValue
-		}
-		car.AppType = value
-		logger.Info("appType", appType)
-		logger.Info("car.AppType", car.AppType)
-	} else {
-		logger.Info("appType is not present, defaulting to enterprise")
-		car.AppType = int(APP_TYPE_ENTERPRISE_HOSTED)
-	}
-
-	if appProfile, ok := d.GetOk("app_profile"); ok {
-		strappProfile, ok := appProfile.(string)
-		if !ok {
-			logger.Error("create Application failed. app_profile is invalid")
-			return ErrInvalidType
-		}
-		aProfile := AppProfile(strappProfile)
-		value, err := aProfile.ToInt()
-		if err != nil {
-			logger.Error("create Application failed. app_profile is invalid")
-			return ErrInvalidValue
-		}
-		car.AppProfile = value
-		logger.Info("appProfile", appProfile)
-		logger.Info("car.AppProfile", car.AppProfile)
-	} else {
-		logger.Info("appProfile is not present, defaulting to http")
-		car.AppProfile = int(APP_PROFILE_HTTP)
-	}
-
-	if clientAppMode, ok := d.GetOk("client_app_mode"); ok {
-		appMode, ok := clientAppMode.(string)
-		if !ok {
-			logger.Error("create Application failed. clientAppMode is invalid")
-			return ErrInvalidType
-		}
-		aMode := ClientAppMode(appMode)
-		value, err := aMode.ToInt()
-		if err != nil {
-			logger.Error("create Application failed. clientAppMode is invalid")
-			return ErrInvalidValue
-		}
-		car.ClientAppMode = value
-		logger.Info("appMode", clientAppMode)
-		logger.Info("car.ClientAppMode", car.ClientAppMode)
-	} else {
-		logger.Info("appMode is not present, defaulting to tcp")
-		car.ClientAppMode = int(CLIENT_APP_MODE_TCP)
-	}
-	return nil
-}
-
 func (car *CreateAppRequest) CreateApplication(ctx context.Context, ec *EaaClient) (*ApplicationResponse, error) {
 	ec.Logger.Info("create application")
 	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, APPS_URL)
 	var appResp ApplicationResponse
-	createAppResp, err := ec.SendAPIRequest(apiURL, "POST", car, &appResp, false)
+	createAppResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", car, &appResp, false)
 
 	if err != nil {
 		ec.Logger.Error("create Application failed. err", err)
@@ -111,10 +28,9 @@ func (car *CreateAppRequest) CreateApplication(ctx context.Context, ec *EaaClien
 	}
 
 	if createAppResp.StatusCode != http.StatusOK {
-		desc, _ := FormatErrorResponse(createAppResp)
-		createErrMsg := fmt.Errorf("%w: %s", ErrAppCreate, desc)
+		createErrMsg := NewAPIError(createAppResp, ErrAppCreate)
 
-		ec.Logger.Error("create Application failed. StatusCode %d %s", createAppResp.StatusCode, desc)
+		ec.Logger.Error("create Application failed", "status", createAppResp.StatusCode, "error", createErrMsg)
 		return nil, createErrMsg
 	}
 	ec.Logger.Info("create Application succeeded.", "name", car.Name)
@@ -162,10 +78,17 @@ type Application struct {
 	RDPVersion             string  `json:"rdp_version"`
 	SupportedClientVersion int     `json:"supported_client_version"`
 
+	// TLSCipherSuites are the cipher suites the application can select with TLSSuiteName, keyed by name
+	TLSCipherSuites map[string]TLSCipherSuite `json:"tls_cipher_suite,omitempty"`
+
 	SAML              bool `json:"saml"`
 	Oidc              bool `json:"oidc"`
 	FQDNBridgeEnabled bool `json:"fqdn_bridge_enabled"`
 	WSFED             bool `json:"wsfed"`
+
+	SAMLSettings  []SAMLConfig  `json:"saml_settings,omitempty"`
+	OIDCSettings  *OIDCSettings `json:"oidc_settings,omitempty"`
+	WSFedSettings []WSFedConfig `json:"wsfed_settings,omitempty"`
 }
 
 func (app *Application) FromResponse(ar *ApplicationResponse) {
@@ -217,6 +140,7 @@ func (app *Application) FromResponse(ar *ApplicationResponse) {
 	if ar.TLSSuiteName != nil {
 		app.TLSSuiteName = ar.TLSSuiteName
 	}
+	app.TLSCipherSuites = ar.TLSCipherSuites
 	if ar.AppProfileID != nil {
 		app.AppProfileID = ar.AppProfileID
 	}
@@ -227,363 +151,213 @@ func (app *Application) FromResponse(ar *ApplicationResponse) {
 	app.Oidc = ar.Oidc
 	app.FQDNBridgeEnabled = ar.FQDNBridgeEnabled
 	app.WSFED = ar.WSFED
+	app.SAMLSettings = ar.SAMLSettings
+	app.OIDCSettings = ar.OIDCSettings
+	app.WSFedSettings = ar.WSFedSettings
 }
 
-func (app *Application) UpdateG2O(ec *EaaClient) (*G2O_Response, error) {
+func (app *Application) UpdateG2O(ctx context.Context, ec *EaaClient) (*G2O_Response, error) {
 	ec.Logger.Info("updateG2O")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/g2o", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 
 	var g2oResp G2O_Response
-	g2ohttpResp, err := ec.SendAPIRequest(apiURL, "POST", nil, &g2oResp, false)
+	g2ohttpResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", nil, &g2oResp, false)
 	if err != nil {
 		ec.Logger.Error("g2o request failed. err: ", err)
 		return nil, err
 	}
 	if !(g2ohttpResp.StatusCode >= http.StatusOK && g2ohttpResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(g2ohttpResp)
-		g2oErrMsg := fmt.Errorf("%w: %s", ErrAppUpdate, desc)
+		g2oErrMsg := NewAPIError(g2ohttpResp, ErrAppUpdate)
 
-		ec.Logger.Error("g2o request failed. g2ohttpResp.StatusCode: desc: ", g2ohttpResp.StatusCode, desc)
+		ec.Logger.Error("g2o request failed", "status", g2ohttpResp.StatusCode, "error", g2oErrMsg)
 		return nil, g2oErrMsg
 	}
 	return &g2oResp, nil
 }
 
-func (app *Application) UpdateEdgeAuthentication(ec *EaaClient) (*EdgeAuth_Response, error) {
+func (app *Application) UpdateEdgeAuthentication(ctx context.Context, ec *EaaClient) (*EdgeAuth_Response, error) {
 	ec.Logger.Info("UpdateEdgeAuthentication")
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/edgekey", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 
 	var edgeAuthResp EdgeAuth_Response
-	edgeAuthhttpResp, err := ec.SendAPIRequest(apiURL, "POST", nil, &edgeAuthResp, false)
+	edgeAuthhttpResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", nil, &edgeAuthResp, false)
 	if err != nil {
 		ec.Logger.Error("edge auth request failed. err: ", err)
 		return nil, err
 	}
 	if !(edgeAuthhttpResp.StatusCode >= http.StatusOK && edgeAuthhttpResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(edgeAuthhttpResp)
-		edgeuthErrMsg := fmt.Errorf("%w: %s", ErrAppUpdate, desc)
+		edgeuthErrMsg := NewAPIError(edgeAuthhttpResp, ErrAppUpdate)
 
-		ec.Logger.Error("edge authentication cookie request failed. edgeAuthhttpResp.StatusCode: desc: ", edgeAuthhttpResp.StatusCode, desc)
+		ec.Logger.Error("edge authentication cookie request failed", "status", edgeAuthhttpResp.StatusCode, "error", edgeuthErrMsg)
 		return nil, edgeuthErrMsg
 	}
 	return &edgeAuthResp, nil
 }
 
-func (app *Application) DeployApplication(ec *EaaClient) error {
+func (app *Application) DeployApplication(ctx context.Context, ec *EaaClient) error {
+	return app.DeployApplicationWithNote(ctx, ec, DEFAULT_DEPLOY_NOTE)
+}
+
+// DeployApplicationWithNote deploys the application, note is shown in the deployment history
+func (app *Application) DeployApplicationWithNote(ctx context.Context, ec *EaaClient, note string) error {
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/deploy", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 	data := map[string]interface{}{
-		"deploy_note": "deploying the app managed through terraform",
+		"deploy_note": note,
 	}
-	deployResp, err := ec.SendAPIRequest(apiURL, "POST", data, nil, false)
+	deployResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", data, nil, false)
 	if err != nil {
 		return err
 	}
 
 	if !(deployResp.StatusCode >= http.StatusOK && deployResp.StatusCode < http.StatusMultipleChoices) {
-		return ErrDeploy
-	}
-	return nil
-}
-
-func (app *Application) DeleteApplication(ec *EaaClient) error {
-	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
-
-	deleteResp, err := ec.SendAPIRequest(apiURL, http.MethodDelete, nil, nil, false)
-	if err != nil {
-		return err
-	}
-
-	if !(deleteResp.StatusCode >= http.StatusOK && deleteResp.StatusCode < http.StatusMultipleChoices) {
-		return ErrAppDelete
+		return NewAPIError(deployResp, ErrDeploy)
 	}
 	return nil
 }
 
-type ApplicationUpdateRequest struct {
-	Application
-	AdvancedSettings AdvancedSettings_Complete `json:"advanced_settings"`
-	Domain           string                    `json:"domain"`
-}
-
-func (appUpdateReq *ApplicationUpdateRequest) UpdateAppRequestFromSchema(ctx context.Context, d *schema.ResourceData, ec *EaaClient) error {
-	ec.Logger.Info("updating application")
-	appUpdateReq.TunnelInternalHosts = []TunnelInternalHost{}
-	if tunnelInternalHosts, ok := d.GetOk("tunnel_internal_hosts"); ok {
-		if tunnelInternalHostsList, ok := tunnelInternalHosts.([]interface{}); ok {
-			for _, th := range tunnelInternalHostsList {
-				if thData, ok := th.(map[string]interface{}); ok {
-					tunnelInternalHost := TunnelInternalHost{}
-					if h, ok := thData["host"].(string); ok {
-						tunnelInternalHost.Host = h
-					}
-					if pr, ok := thData["port_range"].(string); ok {
-						tunnelInternalHost.PortRange = pr
-					}
-					if pt, ok := thData["proto_type"].(int); ok {
-						tunnelInternalHost.ProtoType = pt
-					}
-					appUpdateReq.TunnelInternalHosts = append(appUpdateReq.TunnelInternalHosts, tunnelInternalHost)
-				}
+// WaitForDeployment polls the application every interval until it is deployed and operational.
+// It fails when the deployment fails or ctx is done, the error reports the last status of the application.
+func WaitForDeployment(ctx context.Context, ec *EaaClient, app_uuid_url string, interval time.Duration) (*ApplicationDataModel, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last *ApplicationDataModel
+	for {
+		app, err := GetApplication(ctx, ec, app_uuid_url)
+		if err != nil {
+			if ctx.Err() != nil && last != nil {
+				return last, deploymentTimeout(ctx, last)
 			}
+			return nil, err
 		}
-	}
-
-	if ac, ok := d.GetOk("app_category"); ok {
-		if acValue, ok := ac.(string); ok {
-
-			if acValue != "" {
-				uuid, err := GetAppCategoryUuid(ec, acValue)
-				if err == nil {
-					category := AppCategory{}
-					category.Name = acValue
-					category.UUID_URL = uuid
-					appUpdateReq.AppCategory = category
-				}
-			}
+		last = app
+		if AppStatusInt(app.AppStatus) == APP_STATUS_FAILED {
+			return app, fmt.Errorf("%w: deployment failed, app_deployed %t, app_status %d, app_operational %d",
+				ErrDeploy, app.AppDeployed, app.AppStatus, app.AppOperational)
 		}
-	}
-
-	if advSettingsData, ok := d.GetOk("advanced_settings"); ok {
-		if advSettingsList, ok := advSettingsData.([]interface{}); ok {
-			if advSettingsList != nil {
-				if len(advSettingsList) > 0 {
-					if advSettingsData, ok := advSettingsList[0].(map[string]interface{}); ok {
-
-						advSettings := AdvancedSettings{}
-
-						if isSSL, ok := advSettingsData["is_ssl_verification_enabled"].(string); ok {
-							advSettings.IsSSLVerificationEnabled = isSSL
-						}
-						if internal_hostname, ok := advSettingsData["internal_hostname"].(string); ok {
-							advSettings.InternalHostname = &internal_hostname
-						}
-						if internal_host_port, ok := advSettingsData["internal_host_port"].(string); ok {
-							advSettings.InternalHostPort = internal_host_port
-						}
-						if wildcard_internal_hostname, ok := advSettingsData["wildcard_internal_hostname"].(string); ok {
-							advSettings.WildcardInternalHostname = wildcard_internal_hostname
-						}
-						if ip_access_allow, ok := advSettingsData["ip_access_allow"].(string); ok {
-							advSettings.IPAccessAllow = ip_access_allow
-						}
-
-						if x_wapp_read_timeout, ok := advSettingsData["x_wapp_read_timeout"].(string); ok {
-							advSettings.XWappReadTimeout = x_wapp_read_timeout
-						}
-						if icr, ok := advSettingsData["ignore_cname_resolution"].(string); ok {
-							advSettings.IgnoreCnameResolution = icr
-						}
-						if g2o, ok := advSettingsData["g2o_enabled"].(string); ok {
-							advSettings.G2OEnabled = g2o
-							if g2o == STR_TRUE {
-
-								g2oResp, err := appUpdateReq.Application.UpdateG2O(ec)
-								if err != nil {
-									ec.Logger.Error("g2o request failed. err: ", err)
-									return err
-								}
-								advSettings.G2OEnabled = STR_TRUE
-								advSettings.G2OKey = &g2oResp.G2OKey
-								advSettings.G2ONonce = &g2oResp.G2ONonce
-
-							}
-						}
-						if edgeAuth, ok := advSettingsData["edge_authentication_enabled"].(string); ok {
-							advSettings.EdgeAuthenticationEnabled = edgeAuth
-							if edgeAuth == STR_TRUE {
-
-								edgeAuthResp, err := appUpdateReq.Application.UpdateEdgeAuthentication(ec)
-								if err != nil {
-									ec.Logger.Error("edge auth cookie request failed. err: ", err)
-									return err
-								}
-								advSettings.EdgeAuthenticationEnabled = STR_TRUE
-								advSettings.EdgeCookieKey = &edgeAuthResp.EdgeCookieKey
-								advSettings.SlaObjectUrl = &edgeAuthResp.SlaObjectUrl
-							}
-						}
-						UpdateAdvancedSettings(&appUpdateReq.AdvancedSettings, advSettings)
-
-						// appUpdateReq.AdvancedSettings = advSettings
-					}
-				}
-			}
+		if app.AppDeployed && AppStatusInt(app.AppStatus) == APP_STATUS_DEPLOYED && app.AppOperational == APP_OPERATIONAL {
+			return app, nil
 		}
-	}
-	appUpdateReq.Servers = []Server{}
-	if servers, ok := d.GetOk("servers"); ok {
-		if serversList, ok := servers.([]interface{}); ok {
-			for _, s := range serversList {
-				if sData, ok := s.(map[string]interface{}); ok {
-					server := Server{}
-					if oh, ok := sData["origin_host"].(string); ok {
-						server.OriginHost = oh
-					}
-					if ot, ok := sData["orig_tls"].(bool); ok {
-						server.OrigTLS = ot
-					}
-					if op, ok := sData["origin_port"].(int); ok {
-						server.OriginPort = op
-					}
-					if opr, ok := sData["origin_protocol"].(string); ok {
-						server.OriginProtocol = opr
-					}
-					appUpdateReq.Servers = append(appUpdateReq.Servers, server)
-				}
-			}
-		}
-	}
+		ec.Logger.Debug("waiting for deployment", "uuid", app_uuid_url, "app_status", app.AppStatus, "app_operational", app.AppOperational)
 
-	if bookmarkURL, ok := d.GetOk("bookmark_url"); ok {
-		if bm, ok := bookmarkURL.(string); ok {
-			appUpdateReq.BookmarkURL = bm
+		select {
+		case <-ctx.Done():
+			return app, deploymentTimeout(ctx, app)
+		case <-ticker.C:
 		}
 	}
+}
 
-	if host, ok := d.GetOk("host"); ok {
-		if hv, ok := host.(string); ok {
-			appUpdateReq.Host = &hv
-		}
-	}
+func deploymentTimeout(ctx context.Context, app *ApplicationDataModel) error {
+	return fmt.Errorf("%w: not deployed and operational in time, app_deployed %t, app_status %d, app_operational %d: %s",
+		ErrDeploy, app.AppDeployed, app.AppStatus, app.AppOperational, ctx.Err())
+}
 
-	if authEnabled, ok := d.GetOk("auth_enabled"); ok {
-		if ae, ok := authEnabled.(string); ok {
-			appUpdateReq.AuthEnabled = ae
-		}
-	}
+func (app *Application) DeleteApplication(ctx context.Context, ec *EaaClient) error {
+	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, APPS_URL, app.UUIDURL)
 
-	if popRegion, ok := d.GetOk("popregion"); ok {
-		if popregionstr, ok := popRegion.(string); ok {
-			appUpdateReq.POPRegion = popregionstr
-			if popRegion != "" {
-				popname, uuid, err := GetPopUuid(ec, popregionstr)
-				if err == nil {
-					appUpdateReq.POPName = popname
-					appUpdateReq.POP = uuid
-				}
-			}
-		}
+	deleteResp, err := ec.SendAPIRequest(ctx, apiURL, http.MethodDelete, nil, nil, false)
+	if err != nil {
+		return err
 	}
 
-	if domain, ok := d.GetOk("domain"); ok {
-		if strDomain, ok := domain.(string); ok {
-			appDomain := Domain(strDomain)
-			value, err := appDomain.ToInt()
-			if err != nil {
-				ec.Logger.Error("Update Application failed. Domain is invalid")
-				return ErrInvalidValue
-			}
-			appUpdateReq.Domain = strconv.Itoa(value)
-
-			if appDomain == AppDomainCustom {
-				if err := processCustomDomain(ec, appUpdateReq, d, ctx); err != nil {
-					ec.Logger.Error("Custom domain processing failed: ", err)
-					return err
-				}
-			}
-		}
-	} else {
-		appUpdateReq.Domain = strconv.Itoa(int(APP_DOMAIN_WAPP))
+	if !(deleteResp.StatusCode >= http.StatusOK && deleteResp.StatusCode < http.StatusMultipleChoices) {
+		return NewAPIError(deleteResp, ErrAppDelete)
 	}
-
-	fmt.Printf("updating application - advanced settings")
-	fmt.Printf("%+v\n", appUpdateReq)
-	fmt.Printf("%+v\n", appUpdateReq.AdvancedSettings)
 	return nil
 }
 
-func processCustomDomain(ec *EaaClient, appUpdateReq *ApplicationUpdateRequest, d *schema.ResourceData, ctx context.Context) error {
-	ec.Logger.Info("Custom domain")
-
-	// Default certificate type to "self-signed"
-	certType := "self_signed"
+// GetApplication returns the application with its advanced settings
+func GetApplication(ctx context.Context, ec *EaaClient, app_uuid_url string) (*ApplicationDataModel, error) {
+	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, APPS_URL, app_uuid_url)
+	var appResp ApplicationDataModel
 
-	// Check if 'cert_type' is specified in the Terraform input
-	if cert, ok := d.GetOk("cert_type"); ok {
-		if certStr, ok := cert.(string); ok {
-			certType = certStr
-		} else {
-			return fmt.Errorf("cert_type is not a valid string")
-		}
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, http.MethodGet, nil, &appResp, false)
+	if err != nil {
+		return nil, err
 	}
+	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
+		return nil, NewAPIError(getResp, ErrGetApp)
+	}
+	return &appResp, nil
+}
 
-	// Convert certificate type to CertType
-	appCert := CertType(certType)
-	ec.Logger.Info("Certificate type: ", appCert)
-
-	// Check if the certificate type is self-signed
-	if appCert == CertSelfSigned {
-		// Check if a self-signed certificate exists for the given hostname
-		certObj, err := DoesSelfSignedCertExistForHost(ec, *appUpdateReq.Host)
-		if err != nil {
-			return fmt.Errorf("failed to check self-signed certificate existence: %w", err)
-		}
-
-		if certObj != nil {
-			// Use existing self-signed certificate
-			appUpdateReq.Cert = &certObj.UUIDURL
-			ec.Logger.Info("Using existing self-signed certificate: ", appUpdateReq.Cert)
-			return nil
-		} else {
-			ec.Logger.Info("Generating self-signed certificate")
-			// Create a new self-signed certificate
-			var certReq CreateSelfSignedCertRequest
-			certReq.HostName = *appUpdateReq.Host
-			certReq.CertType = CERT_TYPE_APP_SSC
-			certResp, err := certReq.CreateSelfSignedCertificate(ctx, ec)
-			if err != nil {
-				return fmt.Errorf("failed to generate self-signed certificate: %w", err)
-			}
+// GetApplications returns every application of the tenant
+func GetApplications(ctx context.Context, ec *EaaClient) ([]ApplicationDataModel, error) {
+	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, APPS_URL)
+	return ListAll[ApplicationDataModel](ctx, ec, apiURL, false, ErrAppsGet)
+}
 
-			// Update application request with the generated certificate
-			appUpdateReq.Cert = &certResp.UUIDURL
-			ec.Logger.Info("Generated self-signed certificate: ", appUpdateReq.Cert)
-			return nil
+// MatchApplicationNames returns the applications whose name is name, ignoring case.
+// When no name is equal, name is a glob pattern, also ignoring case, so * and ? select several names
+// while a name holding * or ? is still found by its exact name.
+func MatchApplicationNames(apps []ApplicationDataModel, name string) []ApplicationDataModel {
+	var matches []ApplicationDataModel
+	for _, app := range apps {
+		if strings.EqualFold(app.Name, name) {
+			matches = append(matches, app)
 		}
 	}
-	if appCert == CertUploaded {
-		cert, ok := d.GetOk("cert_name")
-		if !ok {
-			return fmt.Errorf("uploaded cert name is missing")
-		}
-		certStr, ok := cert.(string)
-		if !ok || certStr == "" {
-			return fmt.Errorf("cert_name is not a valid string")
-		}
+	if len(matches) > 0 {
+		return matches
+	}
 
-		// Check if the uploaded certificate exists for the given certname
-		certObj, err := DoesUploadedCertExist(ec, certStr)
-		if err != nil || certObj == nil {
-			return fmt.Errorf("the uploaded cert does not exist: %w", err)
+	pattern := strings.ToLower(name)
+	for _, app := range apps {
+		if globMatch(pattern, strings.ToLower(app.Name)) {
+			matches = append(matches, app)
 		}
-
-		if certObj != nil {
-			// Use existing self-signed certificate
-			appUpdateReq.Cert = &certObj.UUIDURL
-			ec.Logger.Info("using uploaded cert : ", appUpdateReq.Cert)
-			return nil
+	}
+	return matches
+}
+
+// globMatch reports whether s matches pattern, where * matches any sequence of characters, / included,
+// and ? any single character. Every other character, \ and [ included, only matches itself.
+func globMatch(pattern, s string) bool {
+	p, str := []rune(pattern), []rune(s)
+	pi, si := 0, 0
+	// star is the index of the last * of pattern and next the index in s it is retried from
+	star, next := -1, 0
+	for si < len(str) {
+		switch {
+		case pi < len(p) && p[pi] == '*':
+			star, next = pi, si
+			pi++
+		case pi < len(p) && (p[pi] == '?' || p[pi] == str[si]):
+			pi++
+			si++
+		case star >= 0:
+			// let the last * match one more character
+			next++
+			pi, si = star+1, next
+		default:
+			return false
 		}
 	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
 
-	return nil
+type ApplicationUpdateRequest struct {
+	Application
+	AdvancedSettings AdvancedSettings_Complete `json:"advanced_settings"`
+	Domain           string                    `json:"domain"`
 }
 
 func (appUpdateReq *ApplicationUpdateRequest) UpdateApplication(ctx context.Context, ec *EaaClient) error {
 	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, APPS_URL, appUpdateReq.UUIDURL)
-	ec.Logger.Info(apiURL)
-	b, _ := json.Marshal(appUpdateReq)
-	fmt.Println(string(b))
+	ec.Logger.Debug("updating application", "uuid", appUpdateReq.UUIDURL)
 
-	appUpdResp, err := ec.SendAPIRequest(apiURL, "PUT", appUpdateReq, nil, false)
+	appUpdResp, err := ec.SendAPIRequest(ctx, apiURL, "PUT", appUpdateReq, nil, false)
 	if err != nil {
 		ec.Logger.Error("update application failed. err: ", err)
 		return err
 	}
 	if !(appUpdResp.StatusCode >= http.StatusOK && appUpdResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(appUpdResp)
-		updErrMsg := fmt.Errorf("%w: %s", ErrAppUpdate, desc)
+		updErrMsg := NewAPIError(appUpdResp, ErrAppUpdate)
 
-		ec.Logger.Error("update application failed. appUpdResp.StatusCode: desc ", appUpdResp.StatusCode, desc)
+		ec.Logger.Error("update application failed", "status", appUpdResp.StatusCode, "error", updErrMsg)
 		return updErrMsg
 	}
 
@@ -592,8 +366,8 @@ func (appUpdateReq *ApplicationUpdateRequest) UpdateApplication(ctx context.Cont
 
 type ApplicationDataModel struct {
 	Application
-	AdvancedSettings AdvancedSettings `json:"advanced_settings"`
-	Domain           int              `json:"domain"`
+	AdvancedSettings AdvancedSettings_Complete `json:"advanced_settings"`
+	Domain           int                       `json:"domain"`
 }
 
 type Server struct {
@@ -640,6 +414,7 @@ type ApplicationResponse struct {
 	ModifiedAt             string               `json:"modified_at"`
 	Name                   string               `json:"name"`
 	Oidc                   bool                 `json:"oidc"`
+	OIDCSettings           *OIDCSettings        `json:"oidc_settings"`
 	OrigTLS                string               `json:"orig_tls"`
 	OriginHost             *string              `json:"origin_host"`
 	OriginPort             int                  `json:"origin_port"`
@@ -649,6 +424,7 @@ type ApplicationResponse struct {
 	RDPVersion             string               `json:"rdp_version"`
 	Resource               string               `json:"resource"`
 	SAML                   bool                 `json:"saml"`
+	SAMLSettings           []SAMLConfig         `json:"saml_settings"`
 	Servers                []Server             `json:"servers"`
 	SSLCACert              string               `json:"ssl_ca_cert"`
 	Status                 int                  `json:"status"`
@@ -657,6 +433,9 @@ type ApplicationResponse struct {
 	TunnelInternalHosts    []TunnelInternalHost `json:"tunnel_internal_hosts"`
 	UUIDURL                string               `json:"uuid_url"` //Id - to do
 	WSFED                  bool                 `json:"wsfed"`
+	WSFedSettings          []WSFedConfig        `json:"wsfed_settings"`
+
+	TLSCipherSuites map[string]TLSCipherSuite `json:"tls_cipher_suite,omitempty"`
 }
 
 type ResourceStatus struct {
@@ -683,22 +462,6 @@ type EdgeAuth_Response struct {
 	SlaObjectUrl  string `json:"sla_object_url,omitempty"`
 }
 
-type AdvancedSettings struct {
-	IsSSLVerificationEnabled  string  `json:"is_ssl_verification_enabled,omitempty"`
-	IgnoreCnameResolution     string  `json:"ignore_cname_resolution,omitempty"`
-	EdgeAuthenticationEnabled string  `json:"edge_authentication_enabled,omitempty"`
-	G2OEnabled                string  `json:"g2o_enabled,omitempty"`
-	G2ONonce                  *string `json:"g2o_nonce,omitempty"`
-	G2OKey                    *string `json:"g2o_key,omitempty"`
-	XWappReadTimeout          string  `json:"x_wapp_read_timeout,omitempty"`
-	InternalHostname          *string `json:"internal_hostname,omitempty"`
-	InternalHostPort          string  `json:"internal_host_port,omitempty"`
-	WildcardInternalHostname  string  `json:"wildcard_internal_hostname,omitempty"`
-	IPAccessAllow             string  `json:"ip_access_allow,omitempty"`
-	EdgeCookieKey             *string `json:"edge_cookie_key,omitempty"`
-	SlaObjectUrl              *string `json:"sla_object_url,omitempty"`
-}
-
 type AdvancedSettings_Complete struct {
 	LoginURL                     *string `json:"login_url,omitempty"`
 	LogoutURL                    *string `json:"logout_url,omitempty"`
@@ -825,6 +588,31 @@ type OIDCSettings struct {
 	OpenIDMetadata        string `json:"openid_metadata"`
 	TokenEndpoint         string `json:"token_endpoint"`
 	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+
+	OIDCClients []OIDCClient `json:"oidc_clients,omitempty"`
+}
+
+// OIDCClient is a relying party of an OIDC application, ClientID and ClientSecret are generated by EAA
+type OIDCClient struct {
+	ClientName             string      `json:"client_name"`
+	ClientID               string      `json:"client_id,omitempty"`
+	ClientSecret           string      `json:"client_secret,omitempty"`
+	Type                   string      `json:"type"`
+	ResponseType           []string    `json:"response_type"`
+	ImplicitGrant          bool        `json:"implicit_grant"`
+	RedirectURIs           []string    `json:"redirect_uris"`
+	PostLogoutRedirectURIs []string    `json:"post_logout_redirect_uri"`
+	JavaScriptOrigins      []string    `json:"javascript_origins"`
+	Scopes                 []string    `json:"scopes"`
+	Claims                 []OIDCClaim `json:"claims"`
+}
+
+type OIDCClaim struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+	Val   string `json:"val,omitempty"`
+	Src   string `json:"src,omitempty"`
+	Rule  string `json:"rule,omitempty"`
 }
 
 type SAMLSettings struct {
@@ -833,6 +621,41 @@ type SAMLSettings struct {
 	Items SAMLObject `json:"items"`
 }
 
+// SAMLConfig is an entry of saml_settings, the SAML configuration of an application as sent to and returned by the API.
+// SAMLSettings describes the same object as a JSON schema.
+type SAMLConfig struct {
+	SP      SPProperties      `json:"sp"`
+	IDP     IDPProperties     `json:"idp"`
+	Subject SubjectProperties `json:"subject"`
+	Attrmap []AttrMapping     `json:"attrmap"`
+}
+
+// WSFedConfig is an entry of wsfed_settings, the WS-Federation configuration of an application
+type WSFedConfig struct {
+	SP      WSFedSPProperties  `json:"sp"`
+	IDP     WSFedIDPProperties `json:"idp"`
+	Subject SubjectProperties  `json:"subject"`
+	Attrmap []AttrMapping      `json:"attrmap"`
+}
+
+// WSFedSPProperties describes the relying party, EntityID is its realm and DSTURL its reply URL
+type WSFedSPProperties struct {
+	EntityID  string `json:"entity_id"`
+	DSTURL    string `json:"dst_url"`
+	SLOURL    string `json:"slo_url,omitempty"`
+	RespBind  string `json:"resp_bind"`
+	TokenLife int    `json:"token_life"`
+	EncrAlgo  string `json:"encr_algo"`
+}
+
+type WSFedIDPProperties struct {
+	EntityID    string `json:"entity_id"`
+	MetadataURL string `json:"metadata_url,omitempty"`
+	SignAlgo    string `json:"sign_algo"`
+	SignCert    string `json:"sign_cert,omitempty"`
+	SelfSigned  bool   `json:"self_signed"`
+}
+
 type SAMLObject struct {
 	Type       string         `json:"type"`
 	Properties SAMLProperties `json:"properties"`
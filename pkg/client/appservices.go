@@ -5,10 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"sort"
 	"time"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var (
@@ -113,7 +110,7 @@ func (rule AccessRule) CreateAccessRule(ctx context.Context, ec *EaaClient, serv
 		Status:      rule.Status,
 	}
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/rules", URL_SCHEME, ec.Host, SERVICES_URL, service_uuid_url)
-	createRuleResp, err := ec.SendAPIRequest(apiURL, "POST", arReq, nil, false)
+	createRuleResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", arReq, nil, false)
 
 	if err != nil {
 		ec.Logger.Error("create rule failed. err", err)
@@ -121,10 +118,9 @@ func (rule AccessRule) CreateAccessRule(ctx context.Context, ec *EaaClient, serv
 	}
 
 	if createRuleResp.StatusCode != http.StatusOK {
-		desc, _ := FormatErrorResponse(createRuleResp)
-		createErrMsg := fmt.Errorf("%w: %s", ErrRuleCreate, desc)
+		createErrMsg := NewAPIError(createRuleResp, ErrRuleCreate)
 
-		ec.Logger.Error("create Access Rule failed. StatusCode %d %s", createRuleResp.StatusCode, desc)
+		ec.Logger.Error("create Access Rule failed", "status", createRuleResp.StatusCode, "error", createErrMsg)
 		return createErrMsg
 	}
 	ec.Logger.Info("create Access Rule succeeded.", "name", arReq.Name)
@@ -137,13 +133,13 @@ func (rule AccessRule) DeleteAccessRule(ctx context.Context, ec *EaaClient, serv
 		return ErrRuleDelete
 	}
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/rules/%s", URL_SCHEME, ec.Host, SERVICES_URL, service_uuid_url, rule.UUID_URL)
-	deleteResp, err := ec.SendAPIRequest(apiURL, http.MethodDelete, nil, nil, false)
+	deleteResp, err := ec.SendAPIRequest(ctx, apiURL, http.MethodDelete, nil, nil, false)
 	if err != nil {
 		return err
 	}
 
 	if !(deleteResp.StatusCode >= http.StatusOK && deleteResp.StatusCode < http.StatusMultipleChoices) {
-		return ErrRuleDelete
+		return NewAPIError(deleteResp, ErrRuleDelete)
 	}
 	return nil
 }
@@ -168,7 +164,7 @@ func (rule AccessRule) ModifyAccessRule(ctx context.Context, ec *EaaClient, serv
 		Status:      rule.Status,
 	}
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/rules/%s", URL_SCHEME, ec.Host, SERVICES_URL, service_uuid_url, rule.UUID_URL)
-	createRuleResp, err := ec.SendAPIRequest(apiURL, "PUT", arReq, nil, false)
+	createRuleResp, err := ec.SendAPIRequest(ctx, apiURL, "PUT", arReq, nil, false)
 
 	if err != nil {
 		ec.Logger.Error("modify rule failed. err", err)
@@ -177,10 +173,9 @@ func (rule AccessRule) ModifyAccessRule(ctx context.Context, ec *EaaClient, serv
 
 	if !(createRuleResp.StatusCode >= http.StatusOK && createRuleResp.StatusCode < http.StatusMultipleChoices) {
 
-		desc, _ := FormatErrorResponse(createRuleResp)
-		createErrMsg := fmt.Errorf("%w: %s", ErrRuleModify, desc)
+		createErrMsg := NewAPIError(createRuleResp, ErrRuleModify)
 
-		ec.Logger.Error("modify Access Rule failed. StatusCode %d %s", createRuleResp.StatusCode, desc)
+		ec.Logger.Error("modify Access Rule failed", "status", createRuleResp.StatusCode, "error", createErrMsg)
 		return createErrMsg
 	}
 	ec.Logger.Info("modify Access Rule succeeded.", "name", arReq.Name)
@@ -212,7 +207,7 @@ type AppService struct {
 	UUIDURL     string `json:"uuid_url,omitempty"`
 }
 
-func (appService AppService) EnableService(ec *EaaClient) error {
+func (appService AppService) EnableService(ctx context.Context, ec *EaaClient) error {
 	ec.Logger.Info("EnableService")
 	if appService.UUIDURL == "" {
 		ec.Logger.Error("enabling access service failed. empty uuid_url")
@@ -220,80 +215,18 @@ func (appService AppService) EnableService(ec *EaaClient) error {
 	}
 	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, SERVICES_URL, appService.UUIDURL)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "PUT", &appService, nil, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "PUT", &appService, nil, false)
 	if err != nil {
 		return fmt.Errorf("failed to enable app service: %w", err)
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appServiceErrMsg := fmt.Errorf("%w: %s", ErrEnableService, desc)
+		appServiceErrMsg := NewAPIError(getResp, ErrEnableService)
 		return appServiceErrMsg
 	}
 	return nil
 }
 
-func (appService AppService) CreateAppServiceStruct(ec *EaaClient) ([]interface{}, error) {
-	if appService.UUIDURL == "" {
-		ec.Logger.Error("CreateAppServiceStruct failed. empty uuid_url")
-		return nil, fmt.Errorf("creating appservice struct failed. empty uuid_url")
-	}
-
-	response, err := GetAccessControlRules(ec, appService.UUIDURL)
-	if err != nil {
-		ec.Logger.Error("get access control rules failed. err", err)
-		return nil, err
-	}
-
-	if len(response.ACLRules) == 0 {
-		ec.Logger.Error("no ACL rules found in response. err", err)
-		return nil, nil
-	}
-
-	appSvc := make(map[string]interface{})
-	appSvc["service_type"] = "access"
-	appSvc["status"] = appService.Status
-
-	var accessRules []map[string]interface{}
-	var ruleStatus string
-	for _, aclRule := range response.ACLRules {
-
-		if aclRule.Status == ADMIN_STATE_ENABLED {
-			ruleStatus = RULE_ON
-		} else {
-			ruleStatus = RULE_OFF
-		}
-		rule := map[string]interface{}{
-			"name":   aclRule.Name,
-			"status": ruleStatus,
-		}
-
-		var rules []map[string]interface{}
-
-		for _, aclSetting := range aclRule.Settings {
-			ruleMap := map[string]interface{}{
-				"operator": aclSetting.Operator,
-				"type":     aclSetting.Type,
-				"value":    aclSetting.Value,
-			}
-			rules = append(rules, ruleMap)
-		}
-		sort.SliceStable(rules, func(i, j int) bool {
-			return rules[i]["type"].(string) < rules[j]["type"].(string)
-		})
-		rule["rule"] = rules
-		accessRules = append(accessRules, rule)
-
-	}
-
-	sort.SliceStable(accessRules, func(i, j int) bool {
-		return accessRules[i]["name"].(string) < accessRules[j]["name"].(string)
-	})
-
-	appSvc["access_rule"] = accessRules
-	return []interface{}{appSvc}, nil
-}
-
-func GetACLService(ec *EaaClient, app_uuid_url string) (*AppService, error) {
+func GetACLService(ctx context.Context, ec *EaaClient, app_uuid_url string) (*AppService, error) {
 	ec.Logger.Info("GetACLService")
 	if app_uuid_url == "" {
 		ec.Logger.Error("get access service failed. empty uuid_url")
@@ -302,13 +235,12 @@ func GetACLService(ec *EaaClient, app_uuid_url string) (*AppService, error) {
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/services", URL_SCHEME, ec.Host, APPS_URL, app_uuid_url)
 	asResponse := AppServicesResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &asResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &asResponse, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get app services: %w", err)
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appServiceErrMsg := fmt.Errorf("%w: %s", ErrAppServicesGet, desc)
+		appServiceErrMsg := NewAPIError(getResp, ErrAppServicesGet)
 		return nil, appServiceErrMsg
 	}
 
@@ -321,131 +253,11 @@ func GetACLService(ec *EaaClient, app_uuid_url string) (*AppService, error) {
 	return nil, ErrAppServicesGet
 }
 
-func ExtractACLService(ctx context.Context, d *schema.ResourceData, ec *EaaClient) (*ACLService, error) {
-	var aclAccessRules []AccessRule
-	var aclSrv ACLService
-
-	// Read services list from ResourceData
-	servicesRaw, ok := d.Get("service").([]interface{})
-	if !ok {
-		ec.Logger.Info("invalid service configuration")
-		return nil, fmt.Errorf("invalid service configuration")
-	}
-
-	// Iterate through each service
-	for _, svcRaw := range servicesRaw {
-		appSvc, ok := svcRaw.(map[string]interface{})
-		if !ok {
-			ec.Logger.Info("invalid service configuration.")
-			return nil, fmt.Errorf("invalid service configuration")
-		}
-
-		serviceType, ok := appSvc["service_type"].(string)
-		if !ok {
-			ec.Logger.Info("invalid or missing service_type.")
-			return nil, fmt.Errorf("invalid or missing service_type")
-		}
-
-		if serviceType != string(ServiceTypeAccessCtrl) {
-			continue
-		}
-
-		serviceStatus, ok := appSvc["status"].(string)
-		if !ok {
-			ec.Logger.Info("Invalid or missing service status.")
-			return nil, fmt.Errorf("invalid or missing service status")
-		}
-		aclSrv.Status = serviceStatus
-
-		// Extract access rules
-		accessRulesRaw, ok := appSvc["access_rule"].([]interface{})
-		if !ok {
-			ec.Logger.Info("invalid access_rule list")
-			return nil, fmt.Errorf("invalid access_rule list")
-		}
-
-		for _, accessRuleRaw := range accessRulesRaw {
-			accessRule, ok := accessRuleRaw.(map[string]interface{})
-			if !ok {
-				ec.Logger.Info("invalid access_rule configuration.")
-				return nil, fmt.Errorf("invalid access_rule configuration")
-			}
-
-			var rules []ACLSetting
-			rulesRaw, ok := accessRule["rule"].([]interface{})
-			if !ok {
-				ec.Logger.Info("Invalid rule list type.")
-				return nil, fmt.Errorf("invalid rule list")
-			}
-
-			for _, ruleRaw := range rulesRaw {
-				ruleMap, ok := ruleRaw.(map[string]interface{})
-				if !ok {
-					ec.Logger.Info("invalid rule configuration.")
-					return nil, fmt.Errorf("invalid rule configuration")
-				}
-
-				operator, ok := ruleMap["operator"].(string)
-				if !ok {
-					ec.Logger.Info("Invalid or missing rule operator.")
-					return nil, fmt.Errorf("invalid or missing rule operator")
-				}
-
-				ruleType, ok := ruleMap["type"].(string)
-				if !ok {
-					ec.Logger.Info("Invalid or missing rule type.")
-					return nil, fmt.Errorf("invalid or missing rule type")
-				}
-
-				value, ok := ruleMap["value"].(string)
-				if !ok {
-					ec.Logger.Info("invalid or missing rule value.")
-					return nil, fmt.Errorf("invalid or missing rule value")
-				}
-				rule := ACLSetting{
-					Operator: operator,
-					Type:     ruleType,
-					Value:    value,
-				}
-
-				if err := rule.Validate(); err != nil {
-					return nil, fmt.Errorf("invalid rule configuration")
-				}
-
-				rules = append(rules, rule)
-			}
-
-			name, ok := accessRule["name"].(string)
-			if !ok {
-				ec.Logger.Info("Invalid or missing access_rule name.")
-				return nil, fmt.Errorf("invalid or missing access_rule name")
-			}
-
-			status, ok := accessRule["status"].(string)
-			ruleStatus := ADMIN_STATE_DISABLED
-			if !ok || (status != RULE_ON && status != RULE_OFF) {
-				status = RULE_OFF
-			}
-			if status == RULE_ON {
-				ruleStatus = ADMIN_STATE_ENABLED
-			}
-
-			aclAccessRules = append(aclAccessRules, AccessRule{
-				Name:     name,
-				Settings: rules,
-				Status:   ruleStatus,
-			})
-		}
-	}
-	aclSrv.ACLRules = aclAccessRules
-	return &aclSrv, nil
-}
-
 type ACLRulesResponse struct {
 	ACLRules []AccessRule `json:"objects,omitempty"`
 }
 
-func GetAccessControlRules(ec *EaaClient, service_uuid_url string) (*ACLRulesResponse, error) {
+func GetAccessControlRules(ctx context.Context, ec *EaaClient, service_uuid_url string) (*ACLRulesResponse, error) {
 	ec.Logger.Info("GetAccessControlRules")
 	if service_uuid_url == "" {
 		ec.Logger.Error("get access control rules failed. empty uuid_url")
@@ -454,13 +266,12 @@ func GetAccessControlRules(ec *EaaClient, service_uuid_url string) (*ACLRulesRes
 	apiURL := fmt.Sprintf("%s://%s/%s/%s/rules", URL_SCHEME, ec.Host, SERVICES_URL, service_uuid_url)
 	asResponse := ACLRulesResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &asResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &asResponse, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access control rules: %w", err)
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		appServiceErrMsg := fmt.Errorf("%w: %s", ErrAppServicesGet, desc)
+		appServiceErrMsg := NewAPIError(getResp, ErrAppServicesGet)
 		return nil, appServiceErrMsg
 	}
 
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_CACHE_TTL = 5 * time.Minute
+
+	CACHE_KEY_AGENTS        = "agents"
+	CACHE_KEY_POPS          = "pops"
+	CACHE_KEY_APPCATEGORIES = "appcategories"
+	CACHE_KEY_IDPS          = "idps"
+	CACHE_KEY_CERTIFICATES  = "certificates"
+)
+
+// lookupCache keeps the reference collections used to resolve names to UUIDs.
+// Each key has its own lock so concurrent lookups of the same collection share a single fetch.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	mu        sync.Mutex
+	value     interface{}
+	fetchedAt time.Time
+}
+
+func (ec *EaaClient) lookupCache() *lookupCache {
+	ec.cacheOnce.Do(func() {
+		ec.cache = &lookupCache{entries: make(map[string]*cacheEntry)}
+	})
+	return ec.cache
+}
+
+func (c *lookupCache) entry(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &cacheEntry{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// idpDirectoriesCacheKey is the cache key of the directories of an IDP
+func idpDirectoriesCacheKey(idpUUID string) string {
+	return CACHE_KEY_IDPS + "/" + idpUUID + "/directories"
+}
+
+// cachedLookup returns the value stored under key or calls fetch when it is missing or expired.
+// Errors are not cached. A CacheTTL of 0 disables caching.
+// The returned value is shared between callers and must not be modified.
+func cachedLookup[T any](ctx context.Context, ec *EaaClient, key string, fetch func() (T, error)) (T, error) {
+	if ec.CacheTTL <= 0 {
+		return fetch()
+	}
+
+	e := ec.lookupCache().entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if value, ok := e.value.(T); ok && time.Since(e.fetchedAt) < ec.CacheTTL {
+		ec.Logger.Debug("lookup cache hit", "key", key)
+		return value, nil
+	}
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	e.value = value
+	e.fetchedAt = time.Now()
+	return value, nil
+}
+
+// InvalidateCache drops the cached collections stored under keys and below them, e.g. "idps" also drops
+// the directories of every IDP. Every collection is dropped when no key is given.
+// It must be called after creating an object that belongs to a cached collection.
+func (ec *EaaClient) InvalidateCache(keys ...string) {
+	c := ec.lookupCache()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(keys) == 0 {
+		c.entries = make(map[string]*cacheEntry)
+		return
+	}
+	for _, key := range keys {
+		for cached := range c.entries {
+			if cached == key || strings.HasPrefix(cached, key+"/") {
+				delete(c.entries, cached)
+			}
+		}
+	}
+}
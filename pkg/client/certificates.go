@@ -28,18 +28,18 @@ func (sscert *CreateSelfSignedCertRequest) CreateSelfSignedCertificate(ctx conte
 	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, CERTIFICATES_URL)
 
 	var ssCertResp CertificateResponse
-	ssCertHttpResp, err := ec.SendAPIRequest(apiURL, "POST", sscert, &ssCertResp, false)
+	ssCertHttpResp, err := ec.SendAPIRequest(ctx, apiURL, "POST", sscert, &ssCertResp, false)
 	if err != nil {
 		ec.Logger.Error("self certificate generation request failed. err: ", err)
 		return nil, err
 	}
 	if !(ssCertHttpResp.StatusCode >= http.StatusOK && ssCertHttpResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(ssCertHttpResp)
-		ssCertErrMsg := fmt.Errorf("%w: %s", ErrAppUpdate, desc)
+		ssCertErrMsg := NewAPIError(ssCertHttpResp, ErrAppUpdate)
 
-		ec.Logger.Error("self signed certificate generation failed. ssCertHttpResp.StatusCode: desc: ", ssCertHttpResp.StatusCode, desc)
+		ec.Logger.Error("self signed certificate generation failed", "status", ssCertHttpResp.StatusCode, "error", ssCertErrMsg)
 		return nil, ssCertErrMsg
 	}
+	ec.InvalidateCache(CACHE_KEY_CERTIFICATES)
 	return &ssCertResp, nil
 }
 
@@ -78,32 +78,29 @@ type CertsResponse struct {
 	Objects []CertObject `json:"objects"`
 }
 
-func GetCertificates(ec *EaaClient) ([]CertObject, error) {
-	apiURL := fmt.Sprintf("%s://%s/%s/thin", URL_SCHEME, ec.Host, CERTIFICATES_URL)
-	certsResponse := CertsResponse{}
+// GetCertificates returns the certificates of the tenant, served from the lookup cache when possible
+func GetCertificates(ctx context.Context, ec *EaaClient) ([]CertObject, error) {
+	return cachedLookup(ctx, ec, CACHE_KEY_CERTIFICATES, func() ([]CertObject, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s/thin", URL_SCHEME, ec.Host, CERTIFICATES_URL)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &certsResponse, false)
-	if err != nil {
-		return nil, err
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		updErrMsg := fmt.Errorf("%w: %s", ErrCertificatesGet, desc)
-		return nil, updErrMsg
-	}
+		certObjects, err := ListAll[CertObject](ctx, ec, apiURL, false, ErrCertificatesGet)
+		if err != nil {
+			return nil, err
+		}
 
-	var certs []CertObject
-	for _, cert := range certsResponse.Objects {
-		if cert.Name == "" || cert.UUIDURL == "" {
-			continue
+		var certs []CertObject
+		for _, cert := range certObjects {
+			if cert.Name == "" || cert.UUIDURL == "" {
+				continue
+			}
+			certs = append(certs, cert)
 		}
-		certs = append(certs, cert)
-	}
-	return certs, nil
+		return certs, nil
+	})
 }
 
-func DoesSelfSignedCertExistForHost(ec *EaaClient, host string) (*CertObject, error) {
-	certs, err := GetCertificates(ec)
+func DoesSelfSignedCertExistForHost(ctx context.Context, ec *EaaClient, host string) (*CertObject, error) {
+	certs, err := GetCertificates(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
@@ -115,24 +112,23 @@ func DoesSelfSignedCertExistForHost(ec *EaaClient, host string) (*CertObject, er
 	return nil, nil
 }
 
-func GetCertificate(ec *EaaClient, cert_uuid_url string) (*CertificateResponse, error) {
+func GetCertificate(ctx context.Context, ec *EaaClient, cert_uuid_url string) (*CertificateResponse, error) {
 	apiURL := fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, CERTIFICATES_URL, cert_uuid_url)
 	certResponse := CertificateResponse{}
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &certResponse, false)
+	getResp, err := ec.SendAPIRequest(ctx, apiURL, "GET", nil, &certResponse, false)
 	if err != nil {
 		return nil, err
 	}
 	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		updErrMsg := fmt.Errorf("%w: %s", ErrCertificatesGet, desc)
+		updErrMsg := NewAPIError(getResp, ErrCertificatesGet)
 		return nil, updErrMsg
 	}
 	return &certResponse, nil
 }
 
-func DoesUploadedCertExist(ec *EaaClient, host string) (*CertObject, error) {
-	certs, err := GetCertificates(ec)
+func DoesUploadedCertExist(ctx context.Context, ec *EaaClient, host string) (*CertObject, error) {
+	certs, err := GetCertificates(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
@@ -143,3 +139,44 @@ func DoesUploadedCertExist(ec *EaaClient, host string) (*CertObject, error) {
 	}
 	return nil, ErrCertNotExist
 }
+
+// GetAppCertificate returns the uuid_url of the certificate of an application served on a custom domain.
+// A self signed certificate is reused for host or generated when missing,
+// an uploaded certificate is looked up by certName.
+func GetAppCertificate(ctx context.Context, ec *EaaClient, certType CertType, host, certName string) (string, error) {
+	ec.Logger.Debug("resolving application certificate", "cert_type", certType, "host", host)
+	switch certType {
+	case CertSelfSigned:
+		if host == "" {
+			return "", fmt.Errorf("%w: host is required for a self signed certificate", ErrInvalidValue)
+		}
+		certObj, err := DoesSelfSignedCertExistForHost(ctx, ec, host)
+		if err != nil {
+			return "", fmt.Errorf("failed to check self-signed certificate existence: %w", err)
+		}
+		if certObj != nil {
+			ec.Logger.Info("using existing self-signed certificate", "uuid_url", certObj.UUIDURL)
+			return certObj.UUIDURL, nil
+		}
+
+		ec.Logger.Info("generating self-signed certificate")
+		certReq := CreateSelfSignedCertRequest{HostName: host, CertType: CERT_TYPE_APP_SSC}
+		certResp, err := certReq.CreateSelfSignedCertificate(ctx, ec)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+		ec.Logger.Info("generated self-signed certificate", "uuid_url", certResp.UUIDURL)
+		return certResp.UUIDURL, nil
+	case CertUploaded:
+		if certName == "" {
+			return "", fmt.Errorf("uploaded cert name is missing")
+		}
+		certObj, err := DoesUploadedCertExist(ctx, ec, certName)
+		if err != nil || certObj == nil {
+			return "", fmt.Errorf("the uploaded cert does not exist: %w", err)
+		}
+		ec.Logger.Info("using uploaded cert", "uuid_url", certObj.UUIDURL)
+		return certObj.UUIDURL, nil
+	}
+	return "", nil
+}
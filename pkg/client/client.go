@@ -2,11 +2,15 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
 	"github.com/hashicorp/go-hclog"
@@ -19,6 +23,21 @@ type EaaClient struct {
 	Signer           edgegrid.Signer
 	Host             string
 	Logger           hclog.Logger
+
+	// MaxRetries is the number of times a failed request is retried, 0 disables retries
+	MaxRetries int
+	// RetryMinWait is the base delay of the exponential backoff
+	RetryMinWait time.Duration
+	// RetryMaxWait caps the delay between two attempts, including Retry-After values
+	RetryMaxWait time.Duration
+
+	// PageSize is the number of objects requested per page by list calls
+	PageSize int
+
+	// CacheTTL is how long the collections used for name lookups are reused, 0 disables the cache
+	CacheTTL  time.Duration
+	cache     *lookupCache
+	cacheOnce sync.Once
 }
 
 type ErrorResponse struct {
@@ -30,7 +49,9 @@ type ErrorResponse struct {
 }
 
 // Exec will sign and execute the request using the client edgegrid.Config
-func (ec *EaaClient) SendAPIRequest(apiURL string, method string, in interface{}, out interface{}, global bool) (*http.Response, error) {
+// Transient failures are retried up to MaxRetries times, see shouldRetry
+// The request and the waits between retries are bound to ctx
+func (ec *EaaClient) SendAPIRequest(ctx context.Context, apiURL string, method string, in interface{}, out interface{}, global bool) (*http.Response, error) {
 	if !global {
 		parsedURL, err := url.Parse(apiURL)
 		if err != nil {
@@ -45,7 +66,10 @@ func (ec *EaaClient) SendAPIRequest(apiURL string, method string, in interface{}
 		}
 		if method == http.MethodGet {
 			queryParams.Set("expand", "true")
-			queryParams.Set("limit", "0")
+			// list calls made through PageIterator set their own limit and offset
+			if queryParams.Get("limit") == "" {
+				queryParams.Set("limit", "0")
+			}
 		}
 		parsedURL.RawQuery = queryParams.Encode()
 
@@ -54,26 +78,54 @@ func (ec *EaaClient) SendAPIRequest(apiURL string, method string, in interface{}
 		// apiURL = fmt.Sprintf("%s?%s", apiURL, queryParams.Encode())
 	}
 
-	ec.Logger.Info(apiURL)
-	r, _ := http.NewRequest(method, apiURL, nil)
-	r.Header.Set("Content-Type", "application/json")
-
-	r.URL.RawQuery = r.URL.Query().Encode()
+	var body []byte
 	if in != nil {
 		data, err := json.Marshal(in)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrMarshaling, err)
 		}
-
-		r.Body = io.NopCloser(bytes.NewBuffer(data))
-		r.ContentLength = int64(len(data))
+		body = data
 	}
-	ec.Signer.SignRequest(r)
 
-	resp, err := ec.Client.Do(r)
-	if err != nil {
-		return nil, err
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		r, err := http.NewRequestWithContext(ctx, method, apiURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		r.Header.Set("Content-Type", "application/json")
+
+		r.URL.RawQuery = r.URL.Query().Encode()
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+		ec.Signer.SignRequest(r)
+
+		ec.logRequest(r, body, attempt)
+		start := time.Now()
+		resp, err = ec.Client.Do(r)
+		ec.logResponse(r, resp, err, start)
+		if attempt >= ec.MaxRetries || !shouldRetry(r, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		wait := ec.retryWait(attempt, resp)
+		if resp != nil {
+			ec.Logger.Warn("retrying request", "method", method, "url", apiURL, "status", resp.StatusCode, "attempt", attempt+1, "wait", wait)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			ec.Logger.Warn("retrying request", "method", method, "url", apiURL, "error", err, "attempt", attempt+1, "wait", wait)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
+
 	if out != nil &&
 		resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices &&
 		resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusResetContent {
@@ -90,33 +142,66 @@ func (ec *EaaClient) SendAPIRequest(apiURL string, method string, in interface{}
 	return resp, nil
 }
 
-func (ec *EaaClient) SendDeleteApplicationEndpoint(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s://%s/%s/%s", URL_SCHEME, ec.Host, APPS_URL, id), nil)
-	if err != nil {
-		return err
-	}
-	req.URL.RawQuery = req.URL.Query().Encode()
-	ec.Signer.SignRequest(req)
+// SendDeleteApplicationEndpoint deletes the application id, a 404 is reported as an APIError matched by IsNotFound
+func (ec *EaaClient) SendDeleteApplicationEndpoint(ctx context.Context, id string) error {
+	app := Application{UUIDURL: id}
+	return app.DeleteApplication(ctx, ec)
+}
+
+// APIError is returned when the EAA API answers with a non 2xx status.
+// It carries the RFC 7807 problem details and wraps the sentinel error of the failed
+// operation (ErrAppCreate, ErrRuleCreate...) so it can be matched with errors.Is.
+type APIError struct {
+	ErrorResponse
+	StatusCode int
+	Method     string
+	URL        string
+	Err        error
+}
 
-	resp, err := ec.Client.Do(req)
-	if err != nil {
-		return err
+// NewAPIError builds an APIError from a failed response and consumes its body
+func NewAPIError(resp *http.Response, err error) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Err:        err,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			u := *resp.Request.URL
+			u.RawQuery = ""
+			apiErr.URL = u.String()
+		}
+	}
+	if resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		if readErr == nil && len(data) > 0 {
+			_ = json.Unmarshal(data, &apiErr.ErrorResponse)
+		}
 	}
+	return apiErr
+}
 
-	defer resp.Body.Close()
-	return nil
+func (e *APIError) Error() string {
+	detail := e.Detail
+	if detail == "" {
+		detail = e.Title
+	}
+	if detail == "" {
+		detail = fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Err == nil {
+		return detail
+	}
+	return fmt.Sprintf("%s: %s", e.Err, detail)
 }
 
-func FormatErrorResponse(errResp *http.Response) (string, error) {
-	var errResponse ErrorResponse
-	data, err := io.ReadAll(errResp.Body)
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
 
-	if err == nil {
-		err := json.Unmarshal(data, &errResponse)
-		if err != nil {
-			return "", ErrUnmarshaling
-		}
-		return errResponse.Detail, nil
-	}
-	return "", ErrUnmarshaling
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
 }
@@ -0,0 +1,578 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/hashicorp/go-hclog"
+)
+
+func newTestClient(srv *httptest.Server) *EaaClient {
+	return &EaaClient{
+		Client: srv.Client(),
+		Signer: &edgegrid.Config{
+			ClientToken:  "akab-client-token",
+			ClientSecret: "client-secret",
+			AccessToken:  "akab-access-token",
+			MaxBody:      edgegrid.MaxBodySize,
+		},
+		Host:         srv.Listener.Addr().String(),
+		Logger:       hclog.NewNullLogger(),
+		MaxRetries:   3,
+		RetryMinWait: time.Millisecond,
+		RetryMaxWait: 10 * time.Millisecond,
+	}
+}
+
+func TestSendAPIRequestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		statuses     []int
+		wantAttempts int32
+		wantStatus   int
+	}{
+		{"get retried on 502", http.MethodGet, "/crux/v1/mgmt-pop/agents", []int{502, 503, 200}, 3, 200},
+		{"post retried on 429", http.MethodPost, "/crux/v1/mgmt-pop/apps", []int{429, 200}, 2, 200},
+		{"post not retried on 502", http.MethodPost, "/crux/v1/mgmt-pop/apps", []int{502, 200}, 1, 502},
+		{"deploy retried on 502", http.MethodPost, "/crux/v1/mgmt-pop/apps/abc/deploy", []int{502, 200}, 2, 200},
+		{"g2o not retried on 502", http.MethodPost, "/crux/v1/mgmt-pop/apps/abc/g2o", []int{502, 200}, 1, 502},
+		{"edgekey not retried on 502", http.MethodPost, "/crux/v1/mgmt-pop/apps/abc/edgekey", []int{502, 200}, 1, 502},
+		{"post delete retried on 502", http.MethodPost, "/crux/v1/mgmt-pop/apps/abc?method=DELETE", []int{502, 200}, 2, 200},
+		{"bad request not retried", http.MethodGet, "/crux/v1/mgmt-pop/agents", []int{400, 200}, 1, 400},
+		{"gives up after max retries", http.MethodGet, "/crux/v1/mgmt-pop/agents", []int{503, 503, 503, 503, 503}, 4, 503},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				if r.Header.Get("Authorization") == "" {
+					t.Errorf("attempt %d is not signed", n)
+				}
+				if tt.statuses[n-1] == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			ec := newTestClient(srv)
+			resp, err := ec.SendAPIRequest(context.Background(), srv.URL+tt.path, tt.method, map[string]string{"name": "app"}, nil, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestSendAPIRequestCancelled(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ec := newTestClient(srv)
+	ec.RetryMaxWait = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ec.SendAPIRequest(ctx, srv.URL+"/"+AGENTS_URL, http.MethodGet, nil, nil, false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %s", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	ec := &EaaClient{RetryMinWait: time.Second, RetryMaxWait: 8 * time.Second}
+
+	for attempt := 0; attempt < 6; attempt++ {
+		wait := ec.retryWait(attempt, nil)
+		if wait > ec.RetryMaxWait {
+			t.Errorf("attempt %d: wait %s exceeds max %s", attempt, wait, ec.RetryMaxWait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "5")
+	if wait := ec.retryWait(0, resp); wait != 5*time.Second {
+		t.Errorf("Retry-After wait = %s, want 5s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := ec.retryWait(0, resp); wait != ec.RetryMaxWait {
+		t.Errorf("Retry-After wait = %s, want capped %s", wait, ec.RetryMaxWait)
+	}
+}
+
+func TestListAllFollowsPages(t *testing.T) {
+	const total = 7
+	tests := map[string]string{
+		"next with limit":    "%s?limit=%d&offset=%d",
+		"next without limit": "%s?offset=%[3]d",
+	}
+	for name, nextFormat := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				query := r.URL.Query()
+				limit, _ := strconv.Atoi(query.Get("limit"))
+				offset, _ := strconv.Atoi(query.Get("offset"))
+				if limit != 3 {
+					t.Errorf("limit = %d, want 3", limit)
+				}
+
+				page := Page[Connector]{}
+				page.Meta.Limit = limit
+				page.Meta.Offset = offset
+				page.Meta.TotalCount = total
+				for i := offset; i < total && i < offset+limit; i++ {
+					page.Objects = append(page.Objects, Connector{Name: fmt.Sprintf("agent-%d", i), UUIDURL: fmt.Sprintf("uuid-%d", i)})
+				}
+				if offset+limit < total {
+					next := fmt.Sprintf(nextFormat, r.URL.Path, limit, offset+limit)
+					page.Meta.Next = &next
+				}
+				_ = json.NewEncoder(w).Encode(page)
+			}))
+			defer srv.Close()
+
+			ec := newTestClient(srv)
+			ec.PageSize = 3
+			agents, err := ListAll[Connector](context.Background(), ec, srv.URL+"/"+AGENTS_URL, false, ErrAgentsGet)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(agents) != total {
+				t.Errorf("got %d agents, want %d", len(agents), total)
+			}
+			if requests != 3 {
+				t.Errorf("requests = %d, want 3", requests)
+			}
+			for i, agent := range agents {
+				if agent.Name != fmt.Sprintf("agent-%d", i) {
+					t.Errorf("agents[%d] = %s", i, agent.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestListAllKeepsQuery(t *testing.T) {
+	const total = 5
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		query := r.URL.Query()
+		if query.Get("shared") != "true" {
+			t.Errorf("page %d: shared = %q, want the filter of the first page", n, query.Get("shared"))
+		}
+		offset, _ := strconv.Atoi(query.Get("offset"))
+
+		page := Page[AppCate]{}
+		page.Meta.TotalCount = total
+		for i := offset; i < total && i < offset+2; i++ {
+			page.Objects = append(page.Objects, AppCate{Name: fmt.Sprintf("cat-%d", i), UUIDURL: "uuid"})
+		}
+		if offset+2 < total {
+			// the API leaves the filters out of meta.next
+			next := fmt.Sprintf("%s?offset=%d", r.URL.Path, offset+2)
+			page.Meta.Next = &next
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	ec := newTestClient(srv)
+	ec.PageSize = 2
+	cats, err := ListAll[AppCate](context.Background(), ec, srv.URL+"/"+APP_CATEGORIES_URL+"?shared=true", false, ErrAppCategoriesGet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(cats) != total {
+		t.Errorf("got %d categories, want %d", len(cats), total)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestListAllWithoutNext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := Page[AppCate]{}
+		page.Meta.TotalCount = 3
+		if offset < 3 {
+			page.Objects = []AppCate{{Name: fmt.Sprintf("cat-%d", offset), UUIDURL: "uuid"}}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	ec := newTestClient(srv)
+	ec.PageSize = 1
+	cats, err := ListAll[AppCate](context.Background(), ec, srv.URL+"/"+APP_CATEGORIES_URL, false, ErrAppCategoriesGet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(cats) != 3 {
+		t.Errorf("got %d categories, want 3", len(cats))
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{
+			Type:      "/problems/validation",
+			Title:     "Bad Request",
+			Detail:    "app name already exists",
+			ProblemID: "abc-123",
+		})
+	}))
+	defer srv.Close()
+
+	ec := newTestClient(srv)
+	ec.ContractID = "C-1"
+	app := &Application{UUIDURL: "app-uuid"}
+	err := app.DeployApplication(context.Background(), ec)
+	if !errors.Is(err, ErrDeploy) {
+		t.Fatalf("err = %v, want %v", err, ErrDeploy)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err %T is not an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.ProblemID != "abc-123" || apiErr.Method != http.MethodPost {
+		t.Errorf("unexpected APIError %+v", apiErr)
+	}
+	if want := srv.URL + "/" + APPS_URL + "/app-uuid/deploy"; apiErr.URL != want {
+		t.Errorf("URL = %s, want %s", apiErr.URL, want)
+	}
+	if want := ErrDeploy.Error() + ": app name already exists"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestSendDeleteApplicationEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		status       int
+		wantErr      bool
+		wantNotFound bool
+	}{
+		"deleted":      {status: http.StatusOK},
+		"no content":   {status: http.StatusNoContent},
+		"not found":    {status: http.StatusNotFound, wantErr: true, wantNotFound: true},
+		"server error": {status: http.StatusInternalServerError, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/"+APPS_URL+"/app-uuid" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			ec := newTestClient(srv)
+			ec.MaxRetries = 0
+			err := ec.SendDeleteApplicationEndpoint(context.Background(), "app-uuid")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error: %t", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrAppDelete) {
+				t.Errorf("err = %v, want %v", err, ErrAppDelete)
+			}
+			if IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("IsNotFound(%v) = %t, want %t", err, IsNotFound(err), tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestLookupCache(t *testing.T) {
+	var agentRequests, certRequests int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/"+AGENTS_URL:
+			atomic.AddInt32(&agentRequests, 1)
+			_ = json.NewEncoder(w).Encode(Page[Connector]{Objects: []Connector{{Name: "connector", UUIDURL: "agent-uuid"}}})
+		case r.URL.Path == "/"+CERTIFICATES_URL+"/thin":
+			atomic.AddInt32(&certRequests, 1)
+			_ = json.NewEncoder(w).Encode(Page[CertObject]{Objects: []CertObject{{Name: "app.example.com", UUIDURL: "cert-uuid", CertType: CERT_TYPE_APP_SSC}}})
+		case r.URL.Path == "/"+CERTIFICATES_URL && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(CertificateResponse{Name: "new.example.com", UUIDURL: "new-cert-uuid"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ec := newTestClient(srv)
+	ec.CacheTTL = time.Minute
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uuids, err := GetAgentUUIDs(ctx, ec, []string{"connector"})
+			if err != nil || len(uuids) != 1 || uuids[0] != "agent-uuid" {
+				t.Errorf("GetAgentUUIDs = %v, %v", uuids, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if agentRequests != 1 {
+		t.Errorf("agent requests = %d, want 1", agentRequests)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := DoesSelfSignedCertExistForHost(ctx, ec, "app.example.com"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if certRequests != 1 {
+		t.Errorf("certificate requests = %d, want 1", certRequests)
+	}
+	sscert := &CreateSelfSignedCertRequest{HostName: "new.example.com"}
+	if _, err := sscert.CreateSelfSignedCertificate(ctx, ec); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := DoesSelfSignedCertExistForHost(ctx, ec, "new.example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if certRequests != 2 {
+		t.Errorf("certificate requests = %d after creating a certificate, want 2", certRequests)
+	}
+
+	ec.CacheTTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, err := GetAgents(ctx, ec); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if agentRequests != 2 {
+		t.Errorf("agent requests = %d after expiry, want 2", agentRequests)
+	}
+}
+
+func TestSendAPIRequestRedactsLogs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"uuid_url":"app-uuid","advanced_settings":{"g2o_key":"response-secret","g2o_enabled":"true"}}`))
+	}))
+	defer srv.Close()
+
+	var logs strings.Builder
+	ec := newTestClient(srv)
+	ec.Logger = hclog.New(&hclog.LoggerOptions{Output: &logs, Level: hclog.Trace})
+
+	in := map[string]interface{}{
+		"name":              "app",
+		"advanced_settings": map[string]interface{}{"edge_cookie_key": "request-secret"},
+		"saml":              []interface{}{map[string]interface{}{"private_key": "pem-secret", "pass_phrase": "phrase-secret"}},
+	}
+	var out map[string]interface{}
+	resp, err := ec.SendAPIRequest(context.Background(), srv.URL+"/"+APPS_URL+"/app-uuid", http.MethodPut, in, &out, false)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected result %v, %v", resp, err)
+	}
+	if out["uuid_url"] != "app-uuid" {
+		t.Errorf("response body was not restored after logging: %v", out)
+	}
+
+	for _, secret := range []string{"response-secret", "request-secret", "pem-secret", "phrase-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs.String())
+		}
+	}
+	for _, want := range []string{"method=PUT", "status=200", "latency=", `\"g2o_enabled\":\"true\"`, REDACTED} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs do not contain %q:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	httpClient, err := NewHTTPClient(TransportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if httpClient.Timeout != DEFAULT_REQUEST_TIMEOUT {
+		t.Errorf("timeout = %s, want %s", httpClient.Timeout, DEFAULT_REQUEST_TIMEOUT)
+	}
+	if _, err := httpClient.Get(srv.URL); err == nil {
+		t.Errorf("expected the test server certificate to be untrusted")
+	}
+
+	httpClient, err = NewHTTPClient(TransportOptions{CACertificates: caPEM, TLSMinVersion: "1.3", Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tlsConfig := httpClient.Transport.(*http.Transport).TLSClientConfig; tlsConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("min TLS version = %x", tlsConfig.MinVersion)
+	}
+	resp, err := httpClient.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request with the CA bundle failed: %s", err)
+	}
+	resp.Body.Close()
+	if _, err := httpClient.Get(srv.URL + "/slow"); err == nil {
+		t.Errorf("expected the request to time out")
+	}
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+	httpClient, err = NewHTTPClient(TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err = httpClient.Get("http://eaa.example.invalid/")
+	if err != nil {
+		t.Fatalf("proxied request failed: %s", err)
+	}
+	resp.Body.Close()
+	if proxied != 1 {
+		t.Errorf("proxied requests = %d, want 1", proxied)
+	}
+
+	for _, opts := range []TransportOptions{
+		{ProxyURL: "proxy.example.com:3128"},
+		{CACertificates: "not a certificate"},
+		{TLSMinVersion: "2.0"},
+	} {
+		if _, err := NewHTTPClient(opts); !errors.Is(err, ErrInvalidTransport) {
+			t.Errorf("NewHTTPClient(%+v) err = %v, want %v", opts, err, ErrInvalidTransport)
+		}
+	}
+}
+
+// TestNoTerraformDependency keeps the package usable outside of Terraform
+func TestNoTerraformDependency(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", nil, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, pkg := range pkgs {
+		for name, file := range pkg.Files {
+			if strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			for _, imp := range file.Imports {
+				if strings.Contains(imp.Path.Value, "github.com/hashicorp/terraform-plugin") {
+					t.Errorf("%s imports %s", name, imp.Path.Value)
+				}
+			}
+		}
+	}
+}
+
+func TestAdvancedSettingsGetSet(t *testing.T) {
+	var settings AdvancedSettings_Complete
+	if err := settings.Set("websocket_enabled", STR_TRUE); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := settings.Set("login_url", "https://login.example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings.WebSocketEnabled != STR_TRUE || settings.LoginURL == nil || *settings.LoginURL != "https://login.example.com" {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+	if value, ok := settings.Get("login_url"); !ok || value != "https://login.example.com" {
+		t.Errorf("login_url = %q, %v", value, ok)
+	}
+	if _, ok := settings.Get("logout_url"); ok {
+		t.Error("logout_url is reported as set")
+	}
+	if err := settings.Set("no_such_setting", STR_TRUE); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestMatchApplicationNames(t *testing.T) {
+	names := []string{"Intranet", "intranet-dev", "wiki", "app[1]", "app*", "apps/jira", `corp\hr`}
+	apps := make([]ApplicationDataModel, 0, len(names))
+	for _, name := range names {
+		app := ApplicationDataModel{}
+		app.Name = name
+		apps = append(apps, app)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{name: "exact", pattern: "wiki", want: []string{"wiki"}},
+		{name: "case", pattern: "INTRANET", want: []string{"Intranet"}},
+		{name: "star", pattern: "intra*", want: []string{"Intranet", "intranet-dev"}},
+		{name: "star case", pattern: "*-DEV", want: []string{"intranet-dev"}},
+		{name: "star in the middle", pattern: "i*t", want: []string{"Intranet"}},
+		{name: "question mark", pattern: "w?k?", want: []string{"wiki"}},
+		{name: "every app", pattern: "*", want: names},
+		{name: "star matches slash", pattern: "apps*", want: []string{"apps/jira"}},
+		{name: "backslash", pattern: `corp\*`, want: []string{`corp\hr`}},
+		{name: "literal star", pattern: "app*", want: []string{"app*"}},
+		{name: "literal bracket", pattern: "app[1]", want: []string{"app[1]"}},
+		{name: "bracket is no class", pattern: "app[12]"},
+		{name: "no match", pattern: "mail*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, app := range MatchApplicationNames(apps, tt.pattern) {
+				got = append(got, app.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
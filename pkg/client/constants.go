@@ -7,6 +7,9 @@ import (
 const (
 	STR_TRUE      = "true"
 	STR_FALSE     = "false"
+	STR_ON        = "on"
+	STR_OFF       = "off"
+	STR_UNBOUNDED = "unbounded"
 	STATE_ENABLED = 1
 )
 
@@ -33,13 +36,14 @@ var (
 	ErrAppCreate = errors.New("app creation failed")
 	ErrAppUpdate = errors.New("app update failed")
 	ErrAppDelete = errors.New("app delete failed")
+	ErrAppsGet   = errors.New("apps get failed")
 
 	ErrAssignAgentsFailure    = errors.New("assigning agents to the app failed")
 	ErrAssignIdpFailure       = errors.New("assigning IDP to the app failed")
 	ErrAssignDirectoryFailure = errors.New("assigning directory to the app failed")
 	ErrDeploy                 = errors.New("app deploy failed")
 	ErrAssignGroupFailure     = errors.New("assigning groups to the app failed")
-	ErrGetApp                 = errors.New("app deploy failed")
+	ErrGetApp                 = errors.New("app get failed")
 
 	ErrInvalidType  = errors.New("value must be of the specified type")
 	ErrInvalidValue = errors.New("invalid value for a key")
@@ -52,15 +56,17 @@ const (
 	AppDomainWapp   Domain = "wapp"
 )
 
+// DOMAINS maps the domain values to their API codes
+var DOMAINS = map[Domain]DomainInt{
+	AppDomainCustom: APP_DOMAIN_CUSTOM,
+	AppDomainWapp:   APP_DOMAIN_WAPP,
+}
+
 func (d Domain) ToInt() (int, error) {
-	switch d {
-	case AppDomainCustom:
-		return int(APP_DOMAIN_CUSTOM), nil
-	case AppDomainWapp:
-		return int(APP_DOMAIN_WAPP), nil
-	default:
-		return 0, errors.New("Unknown domain value")
+	if value, ok := DOMAINS[d]; ok {
+		return int(value), nil
 	}
+	return 0, errors.New("Unknown domain value")
 }
 
 type DomainInt int
@@ -95,29 +101,24 @@ const (
 	AppProfileTCP        AppProfile = "tcp"
 )
 
+// APP_PROFILES maps the app_profile values to their API codes
+var APP_PROFILES = map[AppProfile]AppProfileInt{
+	AppProfileHTTP:       APP_PROFILE_HTTP,
+	AppProfileSharePoint: APP_PROFILE_SHAREPOINT,
+	AppProfileJira:       APP_PROFILE_JIRA,
+	AppProfileRDP:        APP_PROFILE_RDP,
+	AppProfileVNC:        APP_PROFILE_VNC,
+	AppProfileSSH:        APP_PROFILE_SSH,
+	AppProfileJenkins:    APP_PROFILE_JENKINS,
+	AppProfileConfluence: APP_PROFILE_CONFLUENCE,
+	AppProfileTCP:        APP_PROFILE_TCP,
+}
+
 func (ap AppProfile) ToInt() (int, error) {
-	switch ap {
-	case AppProfileHTTP:
-		return int(APP_PROFILE_HTTP), nil
-	case AppProfileSharePoint:
-		return int(APP_PROFILE_SHAREPOINT), nil
-	case AppProfileJira:
-		return int(APP_PROFILE_JIRA), nil
-	case AppProfileRDP:
-		return int(APP_PROFILE_RDP), nil
-	case AppProfileVNC:
-		return int(APP_PROFILE_VNC), nil
-	case AppProfileSSH:
-		return int(APP_PROFILE_SSH), nil
-	case AppProfileJenkins:
-		return int(APP_PROFILE_JENKINS), nil
-	case AppProfileConfluence:
-		return int(APP_PROFILE_CONFLUENCE), nil
-	case AppProfileTCP:
-		return int(APP_PROFILE_TCP), nil
-	default:
-		return 0, errors.New("Unknown App_Profile value")
+	if value, ok := APP_PROFILES[ap]; ok {
+		return int(value), nil
 	}
+	return 0, errors.New("Unknown App_Profile value")
 }
 
 type AppProfileInt int
@@ -166,15 +167,17 @@ const (
 	ClientAppModeTunnel ClientAppMode = "tunnel"
 )
 
+// CLIENT_APP_MODES maps the client_app_mode values to their API codes
+var CLIENT_APP_MODES = map[ClientAppMode]ClientAppModeInt{
+	ClientAppModeTCP:    CLIENT_APP_MODE_TCP,
+	ClientAppModeTunnel: CLIENT_APP_MODE_TUNNEL,
+}
+
 func (cam ClientAppMode) ToInt() (int, error) {
-	switch cam {
-	case ClientAppModeTCP:
-		return int(CLIENT_APP_MODE_TCP), nil
-	case ClientAppModeTunnel:
-		return int(CLIENT_APP_MODE_TUNNEL), nil
-	default:
-		return 0, errors.New("Unknown ClientAppMode value")
+	if value, ok := CLIENT_APP_MODES[cam]; ok {
+		return int(value), nil
 	}
+	return 0, errors.New("Unknown ClientAppMode value")
 }
 
 type ClientAppModeInt int
@@ -204,19 +207,19 @@ const (
 	ClientAppTypeTunnel     ClientAppType = "tunnel"
 )
 
+// CLIENT_APP_TYPES maps the app_type values to their API codes
+var CLIENT_APP_TYPES = map[ClientAppType]ClientAppTypeInt{
+	ClientAppTypeEnterprise: APP_TYPE_ENTERPRISE_HOSTED,
+	ClientAppTypeSaaS:       APP_TYPE_SAAS,
+	ClientAppTypeBookmark:   APP_TYPE_BOOKMARK,
+	ClientAppTypeTunnel:     APP_TYPE_TUNNEL,
+}
+
 func (cat ClientAppType) ToInt() (int, error) {
-	switch cat {
-	case ClientAppTypeEnterprise:
-		return int(APP_TYPE_ENTERPRISE_HOSTED), nil
-	case ClientAppTypeSaaS:
-		return int(APP_TYPE_SAAS), nil
-	case ClientAppTypeBookmark:
-		return int(APP_TYPE_BOOKMARK), nil
-	case ClientAppTypeTunnel:
-		return int(APP_TYPE_TUNNEL), nil
-	default:
-		return 0, errors.New("Unknown ClientAppType value")
+	if value, ok := CLIENT_APP_TYPES[cat]; ok {
+		return int(value), nil
 	}
+	return 0, errors.New("Unknown ClientAppType value")
 }
 
 type ClientAppTypeInt int
@@ -243,12 +246,32 @@ func (cat ClientAppTypeInt) String() (string, error) {
 	}
 }
 
+// AppStatusInt is the deployment state of an application reported in app_status
+type AppStatusInt int
+
+const (
+	APP_STATUS_NOT_DEPLOYED AppStatusInt = iota
+	APP_STATUS_DEPLOYED
+	APP_STATUS_PENDING
+	APP_STATUS_FAILED
+)
+
+// APP_OPERATIONAL is the app_operational value of an application that serves traffic
+const APP_OPERATIONAL = 1
+
+// DEFAULT_DEPLOY_NOTE is the note of the deployments made by DeployApplication
+const DEFAULT_DEPLOY_NOTE = "deploying the app managed through terraform"
+
 type CertType string
 
 const (
 	CertSelfSigned CertType = "self_signed"
 	CertUploaded   CertType = "uploaded"
 )
+
+// CERT_TYPES lists the cert_type values
+var CERT_TYPES = []CertType{CertSelfSigned, CertUploaded}
+
 const (
 	CERT_TYPE_APP = 1 + iota
 	CERT_TYPE_AGENT
@@ -0,0 +1,31 @@
+// Package client is a Go SDK for the Akamai Enterprise Application Access management API
+// (crux/v1/mgmt-pop). It has no dependency on Terraform and works on plain Go structs.
+//
+// Build an EaaClient with an EdgeGrid signer, then call the package functions:
+//
+//	signer, err := edgegrid.New(edgegrid.WithFile("~/.edgerc"))
+//	httpClient, err := client.NewHTTPClient(client.TransportOptions{})
+//	ec := &client.EaaClient{
+//		ContractID: "1-ABCDE",
+//		Client:     httpClient,
+//		Signer:     signer,
+//		Host:       signer.Host,
+//		Logger:     hclog.Default(),
+//		MaxRetries: client.DEFAULT_MAX_RETRIES,
+//		CacheTTL:   client.DEFAULT_CACHE_TTL,
+//	}
+//
+// The API surface is grouped by object:
+//
+//   - Applications: CreateAppRequest.CreateApplication, GetApplication, GetApplications,
+//     ApplicationUpdateRequest.UpdateApplication, Application.DeployApplication, WaitForDeployment and Application.DeleteApplication.
+//   - Connectors: GetAgents, GetAgentUUIDs, AssignAgents.AssignAgents and Application.GetAppAgents.
+//   - Identity: GetIDPS, GetIdpWithName, GetIDPDirectories, AppIdp.AssignIDP, IDPData.AssignIdpDirectories
+//     and Application.GetAppAuthentication.
+//   - Certificates: GetCertificates, GetCertificate, GetAppCertificate and CreateSelfSignedCertRequest.CreateSelfSignedCertificate.
+//   - Services: GetACLService, AppService.EnableService, GetAccessControlRules and the AccessRule methods.
+//   - Reference data: GetPops, GetPopUuid, GetAppCategories and GetAppCategoryUuid.
+//
+// Failed calls return an *APIError wrapping the sentinel error of the operation, e.g. ErrAppCreate,
+// so they can be matched with errors.Is and inspected with errors.As.
+package client
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 )
 
 var (
@@ -48,27 +47,18 @@ type Meta struct {
 func GetIDPS(ctx context.Context, ec *EaaClient) (*IDPList, error) {
 	ec.Logger.Info("getIDPs call")
 
-	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, IDP_URL)
-	ec.Logger.Info(apiURL)
-
-	idpResponse := IDPResponse{}
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &idpResponse, false)
+	idpObjects, err := listIDPs(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		getIdpErrMsg := fmt.Errorf("%w: %s", ErrIDPGet, desc)
-		return nil, getIdpErrMsg
-	}
 
 	idpList := IDPList{}
 	idps := []IDPData{}
-	for _, idp := range idpResponse.IDPS {
+	for _, idp := range idpObjects {
 		if idp.Name == "" || idp.UUIDURL == "" {
 			continue
 		}
-		directoryList, err := GetIDPDirectories(ec, idp.UUIDURL)
+		directoryList, err := GetIDPDirectories(ctx, ec, idp.UUIDURL)
 		if err != nil {
 			return nil, ErrIDPGet
 		}
@@ -83,25 +73,26 @@ func GetIDPS(ctx context.Context, ec *EaaClient) (*IDPList, error) {
 	return &idpList, nil
 }
 
+// listIDPs returns the identity providers of the tenant, served from the lookup cache when possible
+func listIDPs(ctx context.Context, ec *EaaClient) ([]IDPResponseData, error) {
+	return cachedLookup(ctx, ec, CACHE_KEY_IDPS, func() ([]IDPResponseData, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, IDP_URL)
+
+		return ListAll[IDPResponseData](ctx, ec, apiURL, false, ErrIDPGet)
+	})
+}
+
 func GetIdpWithName(ctx context.Context, ec *EaaClient, idpName string) (*IDPData, error) {
 	ec.Logger.Info("GetIdpWithName ", idpName)
-	apiURL := fmt.Sprintf("%s://%s/%s", URL_SCHEME, ec.Host, IDP_URL)
-	ec.Logger.Info(apiURL)
 
-	idpResponse := IDPResponse{}
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &idpResponse, false)
+	idpObjects, err := listIDPs(ctx, ec)
 	if err != nil {
 		return nil, err
 	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		getIdpErrMsg := fmt.Errorf("%w: %s", ErrIDPGet, desc)
-		return nil, getIdpErrMsg
-	}
 
-	for _, idp := range idpResponse.IDPS {
+	for _, idp := range idpObjects {
 		if idp.Name == idpName {
-			directoryList, err := GetIDPDirectories(ec, idp.UUIDURL)
+			directoryList, err := GetIDPDirectories(ctx, ec, idp.UUIDURL)
 			if err != nil {
 				return nil, (ErrIDPGet)
 			}
@@ -121,7 +112,7 @@ func (idpData *IDPData) GetIdpDirectory(ctx context.Context, ec *EaaClient, dirN
 
 	for _, directory := range idpData.Directories {
 		if dirName == directory.Name {
-			ec.Logger.Info(directory.Name)
+			ec.Logger.Debug("directory", "name", directory.Name)
 			return &directory, nil
 		}
 	}
@@ -129,86 +120,88 @@ func (idpData *IDPData) GetIdpDirectory(ctx context.Context, ec *EaaClient, dirN
 	return nil, errors.New("IDP with name not found")
 }
 
-func GetIDPDirectories(ec *EaaClient, idpUUID string) ([]DirectoryData, error) {
-	apiURL := fmt.Sprintf("%s://%s/%s/%s/directories", URL_SCHEME, ec.Host, IDP_URL, idpUUID)
-	ec.Logger.Info("getIDPDirectories for idpUUID ", idpUUID)
-	ec.Logger.Info(apiURL)
-	directoryResponse := DirectoryResponse{}
+// GetIDPDirectories returns the directories of an IDP with their groups, served from the lookup cache when possible
+func GetIDPDirectories(ctx context.Context, ec *EaaClient, idpUUID string) ([]DirectoryData, error) {
+	return cachedLookup(ctx, ec, idpDirectoriesCacheKey(idpUUID), func() ([]DirectoryData, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s/%s/directories", URL_SCHEME, ec.Host, IDP_URL, idpUUID)
+		ec.Logger.Info("getIDPDirectories for idpUUID ", idpUUID)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &directoryResponse, false)
-	if err != nil {
-		return nil, err
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		getIdpDirsErrMsg := fmt.Errorf("%w: %s", ErrIDPDirectoriesGet, desc)
-		return nil, getIdpDirsErrMsg
-	}
-
-	directoryList := []DirectoryData{}
-	for _, directory := range directoryResponse.DirectoryList {
-		if directory.Name == "" || directory.UUID == "" {
-			continue
+		directories, err := ListAll[DirectoryData](ctx, ec, apiURL, false, ErrIDPDirectoriesGet)
+		if err != nil {
+			return nil, err
 		}
-		groupList := []GroupData{}
-		for _, group := range directory.Groups {
-			if group.Name == "" || group.UUID_URL == "" {
+
+		directoryList := []DirectoryData{}
+		for _, directory := range directories {
+			if directory.Name == "" || directory.UUID == "" {
 				continue
 			}
-			groupData := GroupData{
-				Name:     group.Name,
-				UUID_URL: group.UUID_URL,
+			groupList := []GroupData{}
+			for _, group := range directory.Groups {
+				if group.Name == "" || group.UUID_URL == "" {
+					continue
+				}
+				groupData := GroupData{
+					Name:     group.Name,
+					UUID_URL: group.UUID_URL,
+				}
+				groupList = append(groupList, groupData)
 			}
-			groupList = append(groupList, groupData)
-		}
 
-		directoryData := DirectoryData{
-			Name:   directory.Name,
-			UUID:   directory.UUID,
-			Groups: groupList,
+			directoryData := DirectoryData{
+				Name:   directory.Name,
+				UUID:   directory.UUID,
+				Groups: groupList,
+			}
+			directoryList = append(directoryList, directoryData)
 		}
-		directoryList = append(directoryList, directoryData)
-	}
-	return directoryList, nil
+		return directoryList, nil
+	})
+}
+
+// DirectoryAssignment describes a directory of an IDP to assign to an application
+type DirectoryAssignment struct {
+	Name      string
+	EnableMFA *bool
+	// Groups restricts the assignment to these groups, every group of the directory is assigned when empty
+	Groups []GroupAssignment
 }
 
-func (idpData *IDPData) AssignIdpDirectories(ctx context.Context, appDirs interface{}, app_uuid_url string, ec *EaaClient) error {
+// GroupAssignment describes a directory group to assign to an application
+type GroupAssignment struct {
+	Name      string
+	EnableMFA *string
+}
+
+// AssignIdpDirectories assigns the directories of the IDP, and their groups, to the application.
+// Directories that do not belong to the IDP are skipped.
+func (idpData *IDPData) AssignIdpDirectories(ctx context.Context, ec *EaaClient, app_uuid_url string, assignments []DirectoryAssignment) error {
 	ec.Logger.Info("assigning directories to application")
-	if appDirsList, ok := appDirs.([]interface{}); ok {
-		for _, s := range appDirsList {
-			if sData, ok := s.(map[string]interface{}); ok {
-				appdir := AppDirectory{}
-				if dirName, ok := sData["name"].(string); ok {
-					ec.Logger.Info(dirName)
-					if em, ok := sData["enable_mfa"].(bool); ok {
-						appdir.EnableMFA = &em
-					}
-					dirData, err := idpData.GetIdpDirectory(ctx, ec, dirName)
-					if err != nil {
-						ec.Logger.Info("directory with name does not exist")
-						continue
-					}
-					appdir.UUID = dirData.UUID
-					appdir.APP_ID = app_uuid_url
-					err = appdir.AssignIdpDirectory(ctx, ec)
-					if err != nil {
-						ec.Logger.Info("directory assignment failed")
-						return err
-					}
-
-					if appGroupsList, ok := sData["app_groups"].([]interface{}); ok {
-						if len(appGroupsList) > 0 {
-							err = dirData.AssignIdpDirectoryGroups(ctx, ec, app_uuid_url, appGroupsList)
-						} else {
-							err = dirData.AssignAllDirectoryGroups(ctx, ec, app_uuid_url)
-						}
-						if err != nil {
-							ec.Logger.Info("directory groups assignment failed")
-							return err
-						}
-					}
-				}
-			}
+	for _, assignment := range assignments {
+		ec.Logger.Debug("directory", "name", assignment.Name)
+		dirData, err := idpData.GetIdpDirectory(ctx, ec, assignment.Name)
+		if err != nil {
+			ec.Logger.Info("directory with name does not exist")
+			continue
+		}
+		appdir := AppDirectory{
+			APP_ID:    app_uuid_url,
+			UUID:      dirData.UUID,
+			EnableMFA: assignment.EnableMFA,
+		}
+		if err := appdir.AssignIdpDirectory(ctx, ec); err != nil {
+			ec.Logger.Info("directory assignment failed")
+			return err
+		}
+
+		if len(assignment.Groups) > 0 {
+			err = dirData.AssignIdpDirectoryGroups(ctx, ec, app_uuid_url, assignment.Groups)
+		} else {
+			err = dirData.AssignAllDirectoryGroups(ctx, ec, app_uuid_url)
+		}
+		if err != nil {
+			ec.Logger.Info("directory groups assignment failed")
+			return err
 		}
 	}
 	return nil
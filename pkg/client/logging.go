@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const REDACTED = "REDACTED"
+
+// sensitiveFields lists the JSON keys whose values are never written to the logs
+var sensitiveFields = map[string]bool{
+	"g2o_key":         true,
+	"g2o_nonce":       true,
+	"edge_cookie_key": true,
+	"private_key":     true,
+	"pass_phrase":     true,
+	"password":        true,
+	"keytab":          true,
+	"sign_key":        true,
+	"client_secret":   true,
+	"access_token":    true,
+	"client_token":    true,
+}
+
+// redactBody returns a JSON body with the values of sensitiveFields replaced, ready to be logged.
+// Bodies that are not JSON are not logged at all since their content cannot be inspected.
+func redactBody(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Sprintf("<%d bytes of non JSON content>", len(data))
+	}
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return REDACTED
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				if field != nil && field != "" {
+					v[key] = REDACTED
+				}
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+// logURL strips the query string, it only carries the contract and paging parameters
+func logURL(u *url.URL) string {
+	stripped := *u
+	stripped.RawQuery = ""
+	return stripped.String()
+}
+
+// logRequest writes the request at Debug level and its redacted body at Trace level
+func (ec *EaaClient) logRequest(r *http.Request, body []byte, attempt int) {
+	if !ec.Logger.IsDebug() {
+		return
+	}
+	args := []interface{}{"method", r.Method, "url", logURL(r.URL), "attempt", attempt + 1}
+	if ec.Logger.IsTrace() && len(body) > 0 {
+		ec.Logger.Trace("sending request", append(args, "body", redactBody(body))...)
+		return
+	}
+	ec.Logger.Debug("sending request", args...)
+}
+
+// logResponse writes the outcome of a request at Debug level and the redacted response body at Trace level.
+// The body is restored so callers can still read it.
+func (ec *EaaClient) logResponse(r *http.Request, resp *http.Response, err error, start time.Time) {
+	if !ec.Logger.IsDebug() {
+		return
+	}
+	args := []interface{}{"method", r.Method, "url", logURL(r.URL), "latency", time.Since(start)}
+	if err != nil {
+		ec.Logger.Debug("request failed", append(args, "error", err)...)
+		return
+	}
+	args = append(args, "status", resp.StatusCode)
+	if ec.Logger.IsTrace() && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr == nil {
+			ec.Logger.Trace("received response", append(args, "body", redactBody(data))...)
+			return
+		}
+	}
+	ec.Logger.Debug("received response", args...)
+}
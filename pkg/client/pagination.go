@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	DEFAULT_PAGE_SIZE = 100
+)
+
+// Page is the envelope returned by the list endpoints
+type Page[T any] struct {
+	Meta    Meta `json:"meta,omitempty"`
+	Objects []T  `json:"objects,omitempty"`
+}
+
+// PageIterator walks a collection one page at a time.
+// It follows meta.next when the API provides it and falls back to offset/total_count otherwise.
+type PageIterator[T any] struct {
+	ec      *EaaClient
+	baseURL *url.URL
+	nextURL string
+	global  bool
+	listErr error
+
+	page    []T
+	fetched int
+	done    bool
+	err     error
+}
+
+// NewPageIterator returns an iterator over the collection at apiURL.
+// listErr is wrapped into the error reported when a page cannot be fetched.
+func NewPageIterator[T any](ec *EaaClient, apiURL string, global bool, listErr error) *PageIterator[T] {
+	it := &PageIterator[T]{
+		ec:      ec,
+		global:  global,
+		listErr: listErr,
+	}
+	baseURL, err := url.Parse(apiURL)
+	if err != nil {
+		it.err = fmt.Errorf("%w: %s", ErrInvalidArgument, err)
+		return it
+	}
+	it.baseURL = baseURL
+	it.nextURL = it.pageURL(0)
+	return it
+}
+
+func (ec *EaaClient) pageSize() int {
+	if ec.PageSize > 0 {
+		return ec.PageSize
+	}
+	return DEFAULT_PAGE_SIZE
+}
+
+// pageURL returns the collection URL with limit and offset set for the page starting at offset
+func (it *PageIterator[T]) pageURL(offset int) string {
+	u := *it.baseURL
+	queryParams := u.Query()
+	queryParams.Set("limit", strconv.Itoa(it.ec.pageSize()))
+	queryParams.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = queryParams.Encode()
+	return u.String()
+}
+
+// nextPageURL returns the meta.next URL u with the query parameters of the collection URL it leaves out,
+// such as shared=true, so that every page lists the same collection.
+// It also sets an explicit limit, so that SendAPIRequest does not add limit=0
+// to a meta.next URL that leaves it out and the page size stays the one of the provider
+func (it *PageIterator[T]) nextPageURL(u *url.URL) string {
+	queryParams := u.Query()
+	for key, values := range it.baseURL.Query() {
+		if !queryParams.Has(key) {
+			queryParams[key] = values
+		}
+	}
+	if queryParams.Get("limit") == "" {
+		queryParams.Set("limit", strconv.Itoa(it.ec.pageSize()))
+	}
+	u.RawQuery = queryParams.Encode()
+	return u.String()
+}
+
+// Next fetches the next page, it returns false once the collection is exhausted or on error
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	currentURL := it.nextURL
+	page := Page[T]{}
+	getResp, err := it.ec.SendAPIRequest(ctx, currentURL, http.MethodGet, nil, &page, it.global)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
+		it.err = NewAPIError(getResp, it.listErr)
+		return false
+	}
+
+	it.page = page.Objects
+	it.fetched += len(page.Objects)
+	if len(page.Objects) == 0 {
+		it.done = true
+		return false
+	}
+
+	switch {
+	case page.Meta.Next != nil && *page.Meta.Next != "":
+		next, err := url.Parse(*page.Meta.Next)
+		if err != nil {
+			it.err = fmt.Errorf("%w: %s", ErrUnmarshaling, err)
+			return true
+		}
+		it.nextURL = it.nextPageURL(it.baseURL.ResolveReference(next))
+	case page.Meta.TotalCount > it.fetched:
+		it.nextURL = it.pageURL(it.fetched)
+	default:
+		it.done = true
+	}
+	if it.nextURL == currentURL {
+		it.done = true
+	}
+	return true
+}
+
+// Page returns the objects of the page fetched by the last call to Next
+func (it *PageIterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// ListAll fetches every page of the collection at apiURL
+func ListAll[T any](ctx context.Context, ec *EaaClient, apiURL string, global bool, listErr error) ([]T, error) {
+	var objects []T
+	it := NewPageIterator[T](ec, apiURL, global, listErr)
+	for it.Next(ctx) {
+		objects = append(objects, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
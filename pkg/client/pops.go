@@ -1,9 +1,9 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 )
 
 var (
@@ -40,45 +40,42 @@ type PopResponse struct {
 	Pops []Pop `json:"objects,omitempty"`
 }
 
-func GetPops(ec *EaaClient) ([]Pop, error) {
-	apiURL := fmt.Sprintf("%s://%s/%s?shared=true", URL_SCHEME, ec.Host, POPS_URL)
-	popsResponse := PopResponse{}
+// GetPops returns the shared pops, served from the lookup cache when possible
+func GetPops(ctx context.Context, ec *EaaClient) ([]Pop, error) {
+	return cachedLookup(ctx, ec, CACHE_KEY_POPS, func() ([]Pop, error) {
+		apiURL := fmt.Sprintf("%s://%s/%s?shared=true", URL_SCHEME, ec.Host, POPS_URL)
 
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &popsResponse, true)
-	if err != nil {
-		return nil, err
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := FormatErrorResponse(getResp)
-		getPopsErrMsg := fmt.Errorf("%w: %s", ErrPopsGet, desc)
-		return nil, getPopsErrMsg
-	}
-
-	var pops []Pop
-	for _, pop := range popsResponse.Pops {
-		if pop.Region == "" || pop.Name == "" || pop.UUIDURL == "" {
-			continue
+		allPops, err := ListAll[Pop](ctx, ec, apiURL, true, ErrPopsGet)
+		if err != nil {
+			return nil, err
 		}
-		popData := Pop{
-			Region:              pop.Region,
-			Description:         pop.Description,
-			Facility:            pop.Facility,
-			Name:                pop.Name,
-			PopCategory:         pop.PopCategory,
-			PopType:             pop.PopType,
-			RelatedFailoverPop:  pop.RelatedFailoverPop,
-			RelatedFailoverName: pop.RelatedFailoverName,
-			UUIDURL:             pop.UUIDURL,
+
+		var pops []Pop
+		for _, pop := range allPops {
+			if pop.Region == "" || pop.Name == "" || pop.UUIDURL == "" {
+				continue
+			}
+			popData := Pop{
+				Region:              pop.Region,
+				Description:         pop.Description,
+				Facility:            pop.Facility,
+				Name:                pop.Name,
+				PopCategory:         pop.PopCategory,
+				PopType:             pop.PopType,
+				RelatedFailoverPop:  pop.RelatedFailoverPop,
+				RelatedFailoverName: pop.RelatedFailoverName,
+				UUIDURL:             pop.UUIDURL,
+			}
+			pops = append(pops, popData)
 		}
-		pops = append(pops, popData)
-	}
 
-	return pops, nil
+		return pops, nil
+	})
 }
 
-func GetPopUuid(ec *EaaClient, popregion string) (string, string, error) {
+func GetPopUuid(ctx context.Context, ec *EaaClient, popregion string) (string, string, error) {
 
-	pops, err := GetPops(ec)
+	pops, err := GetPops(ctx, ec)
 	if err != nil {
 		return "", "", ErrPopsGet
 	}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	DEFAULT_MAX_RETRIES    = 3
+	DEFAULT_RETRY_MIN_WAIT = 1 * time.Second
+	DEFAULT_RETRY_MAX_WAIT = 30 * time.Second
+)
+
+// retrySafePostSuffixes lists POST endpoints that can be replayed without creating duplicates.
+// g2o and edgekey are not listed, each call generates a new key.
+var retrySafePostSuffixes = []string{
+	"/deploy",
+}
+
+// isRetrySafe reports whether a failed request can be sent again without side effects
+func isRetrySafe(method string, u *url.URL) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		if strings.EqualFold(u.Query().Get("method"), http.MethodDelete) {
+			return true
+		}
+		for _, suffix := range retrySafePostSuffixes {
+			if strings.HasSuffix(u.Path, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isConnectionReset reports whether err is a transient transport failure
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// shouldRetry decides if the outcome of a request is worth another attempt.
+// 429 responses are always retried since the API rejected the call before processing it,
+// 5xx responses and connection resets only when the request is safe to replay.
+func shouldRetry(r *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return isConnectionReset(err) && isRetrySafe(r.Method, r.URL)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isRetrySafe(r.Method, r.URL)
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header, either delay-seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func (ec *EaaClient) retryMaxWait() time.Duration {
+	if ec.RetryMaxWait > 0 {
+		return ec.RetryMaxWait
+	}
+	return DEFAULT_RETRY_MAX_WAIT
+}
+
+func (ec *EaaClient) retryMinWait() time.Duration {
+	if ec.RetryMinWait > 0 {
+		return ec.RetryMinWait
+	}
+	return DEFAULT_RETRY_MIN_WAIT
+}
+
+// retryWait returns how long to wait before the next attempt.
+// Retry-After wins when present, otherwise exponential backoff with jitter is used.
+// The result never exceeds RetryMaxWait.
+func (ec *EaaClient) retryWait(attempt int, resp *http.Response) time.Duration {
+	maxWait := ec.retryMaxWait()
+	if wait, ok := retryAfter(resp); ok {
+		if wait > maxWait {
+			return maxWait
+		}
+		return wait
+	}
+
+	wait := ec.retryMinWait()
+	for i := 0; i < attempt && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
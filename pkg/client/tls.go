@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrTLSSuiteNotExist = errors.New("tls cipher suite does not exist")
+)
+
+// CipherSuite returns the cipher suite called name among the ones the application can select,
+// the error wraps ErrTLSSuiteNotExist when there is none
+func (app *Application) CipherSuite(name string) (*TLSCipherSuite, error) {
+	suite, ok := app.TLSCipherSuites[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrTLSSuiteNotExist, name)
+	}
+	return &suite, nil
+}
+
+// CipherSuiteNames returns the names of the cipher suites the application can select in order
+func (app *Application) CipherSuiteNames() []string {
+	names := make([]string, 0, len(app.TLSCipherSuites))
+	for name := range app.TLSCipherSuites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
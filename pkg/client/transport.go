@@ -0,0 +1,95 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DEFAULT_REQUEST_TIMEOUT = 60 * time.Second
+	DEFAULT_MAX_IDLE_CONNS  = 10
+	DEFAULT_TLS_MIN_VERSION = "1.2"
+)
+
+var (
+	ErrInvalidTransport = errors.New("invalid HTTP transport settings")
+)
+
+// TLS_VERSIONS maps the tls_min_version values to their crypto/tls constants
+var TLS_VERSIONS = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TransportOptions configures the http.Client used to reach the EAA API
+type TransportOptions struct {
+	// Timeout bounds a single attempt, retries get a fresh timeout
+	Timeout time.Duration
+	// ProxyURL overrides the HTTPS_PROXY/NO_PROXY environment variables when set
+	ProxyURL string
+	// CACertificates is a PEM bundle trusted in addition to the system roots
+	CACertificates string
+	// TLSMinVersion is one of the TLS_VERSIONS keys
+	TLSMinVersion string
+	// MaxIdleConns is the number of keep-alive connections kept to the API host
+	MaxIdleConns int
+}
+
+// NewHTTPClient builds a dedicated http.Client from opts, zero values fall back to the defaults
+func NewHTTPClient(opts TransportOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil || (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") || proxyURL.Host == "" {
+			return nil, fmt.Errorf("%w: proxy URL %q must be an absolute http or https URL", ErrInvalidTransport, opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsMinVersion := opts.TLSMinVersion
+	if tlsMinVersion == "" {
+		tlsMinVersion = DEFAULT_TLS_MIN_VERSION
+	}
+	minVersion, ok := TLS_VERSIONS[tlsMinVersion]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown TLS version %q", ErrInvalidTransport, tlsMinVersion)
+	}
+	transport.TLSClientConfig = &tls.Config{MinVersion: minVersion}
+
+	if opts.CACertificates != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(opts.CACertificates)) {
+			return nil, fmt.Errorf("%w: no PEM certificate found in the CA certificates", ErrInvalidTransport)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	maxIdleConns := opts.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DEFAULT_MAX_IDLE_CONNS
+	}
+	// every call goes to the same API host
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConns
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_REQUEST_TIMEOUT
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -4,9 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sync"
 )
 
 var (
@@ -14,60 +14,6 @@ var (
 	ErrNotFound = errors.New("key not found")
 )
 
-func SetAttrs(d *schema.ResourceData, AttributeValues map[string]interface{}) error {
-	for attr, value := range AttributeValues {
-		if err := d.Set(attr, value); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func SetAdvancedSettings(d *schema.ResourceData, settings AdvancedSettings) error {
-	advancedSettingsMap := make(map[string]interface{})
-
-	v := reflect.ValueOf(settings)
-	t := v.Type()
-
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		tag := t.Field(i).Tag.Get("json")
-		tagName := strings.Split(tag, ",")[0]
-
-		// Skip fields with empty values
-		if field.Kind() == reflect.Ptr && field.IsNil() {
-			continue
-		}
-
-		if field.Kind() == reflect.Ptr {
-			// Dereference pointer fields
-			advancedSettingsMap[tagName] = field.Elem().Interface()
-		} else {
-			advancedSettingsMap[tagName] = field.Interface()
-		}
-	}
-
-	return d.Set("advanced_settings", advancedSettingsMap)
-}
-
-func GetStringValue(key string, d *schema.ResourceData) (string, error) {
-	if key == "" {
-		return "", fmt.Errorf("%w: %s", ErrEmptyKey, key)
-	}
-
-	value, ok := d.GetOk(key)
-	if ok {
-		str, ok := value.(string)
-		if !ok {
-			return "", fmt.Errorf("%w: %s, %q", ErrInvalidType, key, "string")
-		}
-
-		return str, nil
-	}
-
-	return "", fmt.Errorf("%w: %s", ErrNotFound, key)
-}
-
 func DifferenceIgnoreCase(slice1, slice2 []string) []string {
 	m := make(map[string]bool)
 	for _, item := range slice2 {
@@ -84,22 +30,69 @@ func DifferenceIgnoreCase(slice1, slice2 []string) []string {
 	return diff
 }
 
-func UpdateAdvancedSettings(complete *AdvancedSettings_Complete, delta AdvancedSettings) {
-	completeVal := reflect.ValueOf(complete).Elem()
-	deltaVal := reflect.ValueOf(delta)
+var (
+	advancedSettingsFieldsOnce sync.Once
+	advancedSettingsFields     map[string]int
+)
 
-	for i := 0; i < deltaVal.NumField(); i++ {
-		deltaField := deltaVal.Field(i)
-		completeField := completeVal.FieldByName(deltaVal.Type().Field(i).Name)
+// advancedSettingField returns the index of the AdvancedSettings_Complete field whose json name is key
+func advancedSettingField(key string) (int, error) {
+	advancedSettingsFieldsOnce.Do(func() {
+		t := reflect.TypeOf(AdvancedSettings_Complete{})
+		advancedSettingsFields = make(map[string]int, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			advancedSettingsFields[name] = i
+		}
+	})
+	if key == "" {
+		return 0, ErrEmptyKey
+	}
+	i, ok := advancedSettingsFields[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: advanced setting %q", ErrNotFound, key)
+	}
+	return i, nil
+}
 
-		// Check if the field is set and is of type string or *string
-		if deltaField.IsValid() && completeField.IsValid() && completeField.CanSet() &&
-			(completeField.Kind() == reflect.String || completeField.Kind() == reflect.Ptr) {
-			if deltaField.Kind() == reflect.Ptr && !deltaField.IsNil() {
-				completeField.Set(deltaField)
-			} else if deltaField.Kind() == reflect.String {
-				completeField.Set(deltaField)
-			}
+// Get returns the raw value of the advanced setting whose json name is key, e.g. "websocket_enabled".
+// ok is false when the setting is unknown or not set.
+func (s *AdvancedSettings_Complete) Get(key string) (value string, ok bool) {
+	i, err := advancedSettingField(key)
+	if err != nil {
+		return "", false
+	}
+	field := reflect.ValueOf(s).Elem().Field(i)
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", false
 		}
+		field = field.Elem()
+	}
+	return field.String(), field.String() != ""
+}
+
+// Set stores the raw value of the advanced setting whose json name is key
+func (s *AdvancedSettings_Complete) Set(key string, value string) error {
+	i, err := advancedSettingField(key)
+	if err != nil {
+		return err
+	}
+	field := reflect.ValueOf(s).Elem().Field(i)
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.ValueOf(&value))
+		return nil
+	}
+	field.SetString(value)
+	return nil
+}
+
+// EnumValues returns the sorted keys of one of the enum maps, e.g. APP_PROFILES, as plain strings
+func EnumValues[K ~string, V any](enum map[K]V) []string {
+	values := make([]string, 0, len(enum))
+	for key := range enum {
+		values = append(values, string(key))
 	}
+	sort.Strings(values)
+	return values
 }
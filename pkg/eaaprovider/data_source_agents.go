@@ -80,12 +80,12 @@ func dataSourceAgents() *schema.Resource {
 func dataSourceAgentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	eaaClient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	agents, err := client.GetAgents(eaaClient)
+	agents, err := client.GetAgents(ctx, eaaClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	var connDataList []interface{}
 	for _, conn := range agents {
@@ -104,7 +104,7 @@ func dataSourceAgentsRead(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	if err := d.Set("agents", connDataList); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Set the resource ID
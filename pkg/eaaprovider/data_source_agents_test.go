@@ -1,11 +1,13 @@
 package eaaprovider
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -59,3 +61,21 @@ func testAccEaaAgentsConfig_basic() string {
 	}
 `
 }
+
+func TestDataAgentsRead(t *testing.T) {
+	srv := newTestAPI(t)
+
+	d := schema.TestResourceDataRaw(t, dataSourceAgents().Schema, map[string]interface{}{})
+	if diags := dataSourceAgentsRead(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if d.Id() != "eaa_agents" {
+		t.Errorf("id = %q", d.Id())
+	}
+	if got := d.Get("agents.#"); got != 2 {
+		t.Fatalf("agents.# = %v, want 2", got)
+	}
+	if got := d.Get("agents.0.name"); got != "terraform-test-connector" {
+		t.Errorf("agents.0.name = %v", got)
+	}
+}
@@ -56,12 +56,12 @@ func dataSourceAppCategoriesRead(ctx context.Context, d *schema.ResourceData, m
 
 	eaaClient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	appCats, err := client.GetAppCategories(eaaClient)
+	appCats, err := client.GetAppCategories(ctx, eaaClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var acDataList []interface{}
@@ -74,7 +74,7 @@ func dataSourceAppCategoriesRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if err := d.Set("appcategories", acDataList); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Set the resource ID
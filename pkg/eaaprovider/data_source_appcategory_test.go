@@ -1,9 +1,11 @@
 package eaaprovider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	//"github.com/stretchr/testify/mock"
 	//client "git.source.akamai.com/terraform-provider-eaa/pkg/client" // Adjust this import
 )
@@ -45,3 +47,15 @@ func testAccEaaAppCategoriesConfig_basic() string {
 	}
 `
 }
+
+func TestDataAppCategoryRead(t *testing.T) {
+	srv := newTestAPI(t)
+
+	d := schema.TestResourceDataRaw(t, dataSourceAppCategories().Schema, map[string]interface{}{})
+	if diags := dataSourceAppCategoriesRead(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if got := d.Get("appcategories.0.name"); got != "Finance" {
+		t.Errorf("appcategories.0.name = %v, want Finance", got)
+	}
+}
@@ -69,19 +69,19 @@ func dataSourceIdps() *schema.Resource {
 func dataSourceIdpsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	eaaClient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	idps, err := client.GetIDPS(ctx, eaaClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if idps != nil {
 		idpListSchema := convertToSchemaType(idps.IDPS)
 
 		if err := d.Set("idps", idpListSchema); err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		// Set the resource ID
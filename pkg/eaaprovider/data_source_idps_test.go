@@ -0,0 +1,28 @@
+package eaaprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataIdpsRead(t *testing.T) {
+	srv := newTestAPI(t)
+
+	d := schema.TestResourceDataRaw(t, dataSourceIdps().Schema, map[string]interface{}{})
+	if diags := dataSourceIdpsRead(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	checks := map[string]interface{}{
+		"idps.0.name":                        "terraform-idp",
+		"idps.0.directories.0.name":          "Cloud Directory",
+		"idps.0.directories.0.groups.#":      2,
+		"idps.0.directories.0.groups.1.name": "demo_group",
+	}
+	for key, want := range checks {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -82,12 +82,12 @@ func dataSourcePops() *schema.Resource {
 func dataSourcePopsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	eaaClient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	pops, err := client.GetPops(eaaClient)
+	pops, err := client.GetPops(ctx, eaaClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var popDataList []interface{}
@@ -107,7 +107,7 @@ func dataSourcePopsRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	if err := d.Set("pops", popDataList); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Set the resource ID
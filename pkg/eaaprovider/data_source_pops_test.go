@@ -1,12 +1,14 @@
 package eaaprovider
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -60,3 +62,15 @@ func testAccEaaAppPopsConfig_basic() string {
 	}
 `
 }
+
+func TestDataPopsRead(t *testing.T) {
+	srv := newTestAPI(t)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePops().Schema, map[string]interface{}{})
+	if diags := dataSourcePopsRead(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if got := d.Get("pops.0.region"); got != "us-east-1" {
+		t.Errorf("pops.0.region = %v, want us-east-1", got)
+	}
+}
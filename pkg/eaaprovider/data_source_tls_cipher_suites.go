@@ -0,0 +1,98 @@
+package eaaprovider
+
+import (
+	"context"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTLSCipherSuites() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTLSCipherSuitesRead,
+
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the application, EAA lists the suites per application",
+			},
+			"cipher_suites": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the TLS cipher suites the application can select with tls_suite_name, ordered by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the suite",
+						},
+						"ssl_cipher": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The OpenSSL cipher list of the suite",
+						},
+						"ssl_protocols": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The TLS protocols of the suite",
+						},
+						"weak_cipher": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the suite contains weak ciphers",
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the suite is used by applications without tls_suite_name",
+						},
+						"selected": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the application uses the suite",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTLSCipherSuitesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	eaaClient, err := Client(m)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	appID := d.Get("app_id").(string)
+	app, err := client.GetApplication(ctx, eaaClient, appID)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	names := app.CipherSuiteNames()
+	suiteDataList := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		suite := app.TLSCipherSuites[name]
+		suiteDataList = append(suiteDataList, map[string]interface{}{
+			"name":          name,
+			"ssl_cipher":    suite.SSLCipher,
+			"ssl_protocols": suite.SSLProtocols,
+			"weak_cipher":   suite.WeakCipher,
+			"default":       suite.Default,
+			"selected":      suite.Selected,
+		})
+	}
+
+	if err := d.Set("cipher_suites", suiteDataList); err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(appID)
+
+	return nil
+}
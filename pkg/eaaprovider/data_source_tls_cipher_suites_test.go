@@ -0,0 +1,40 @@
+package eaaprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataTLSCipherSuitesRead(t *testing.T) {
+	srv := newTestAPI(t)
+
+	config := testApplicationConfig("tf-app")
+	config["tls_suite_name"] = "TLS-Suite-v1"
+	app := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(context.Background(), app, testMeta(srv)); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceTLSCipherSuites().Schema, map[string]interface{}{"app_id": app.Id()})
+	if diags := dataSourceTLSCipherSuitesRead(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if got := d.Get("cipher_suites.#"); got != 2 {
+		t.Fatalf("cipher_suites.# = %v, want 2", got)
+	}
+	checks := map[string]interface{}{
+		"cipher_suites.0.name":          "TLS-Suite-Legacy",
+		"cipher_suites.0.weak_cipher":   true,
+		"cipher_suites.0.selected":      false,
+		"cipher_suites.1.name":          "TLS-Suite-v1",
+		"cipher_suites.1.ssl_protocols": "TLSv1.2 TLSv1.3",
+		"cipher_suites.1.selected":      true,
+	}
+	for key, want := range checks {
+		if got := d.Get(key); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -4,38 +4,179 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var (
 	ErrInvalidEdgercConfig = errors.New("edgerc config file is not valid")
+	ErrMissingContractID   = errors.New("contract ID is not set")
+	ErrMissingCredentials  = errors.New("EdgeGrid credentials are not set")
 )
 
+const (
+	ENV_CONTRACT_ID        = "EAA_CONTRACT_ID"
+	ENV_ACCOUNT_SWITCH_KEY = "EAA_ACCOUNT_SWITCH_KEY"
+	ENV_EDGERC             = "EAA_EDGERC"
+)
+
+const (
+	CREATE_FAILURE_TAINT    = "taint"
+	CREATE_FAILURE_ROLLBACK = "rollback"
+
+	// ROLLBACK_TIMEOUT bounds the delete of a rollback, which also runs when the create timeout is over or the user cancelled
+	ROLLBACK_TIMEOUT = 2 * time.Minute
+)
+
+// providerMeta is the provider configuration handed to resources and data sources
+type providerMeta struct {
+	*client.EaaClient
+	onCreateFailure string
+}
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"contractid": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The contract ID for the provider.",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(ENV_CONTRACT_ID, nil),
+				Description: "The contract ID for the provider. Defaults to the EAA_CONTRACT_ID environment variable.",
 			},
 			"accountswitchkey": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The account switch key for the provider.",
+				DefaultFunc: schema.EnvDefaultFunc(ENV_ACCOUNT_SWITCH_KEY, nil),
+				Description: "The account switch key for the provider. Defaults to the EAA_ACCOUNT_SWITCH_KEY environment variable.",
 			},
 			"edgerc": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc(ENV_EDGERC, nil),
+				Description:   "The edgerc file path key for the provider. Defaults to the EAA_EDGERC environment variable.",
+				ConflictsWith: []string{"config"},
+			},
+			"config_section": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       edgegrid.DefaultSection,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Description:   "The section of the edgerc file holding the credentials.",
+				ConflictsWith: []string{"config"},
+			},
+			"config": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Inline EdgeGrid credentials, used instead of the edgerc file.",
+				ConflictsWith: []string{"edgerc", "config_section"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "The API host, without scheme.",
+						},
+						"client_token": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "The client token of the API client.",
+						},
+						"client_secret": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "The client secret of the API client.",
+						},
+						"access_token": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "The access token of the API client.",
+						},
+					},
+				},
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DEFAULT_MAX_RETRIES,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of times a transient API failure is retried.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.DEFAULT_RETRY_MAX_WAIT / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum wait in seconds between two retries.",
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DEFAULT_PAGE_SIZE,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of objects requested per page when listing collections.",
+			},
+			"cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.DEFAULT_CACHE_TTL / time.Second),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of seconds agents, pops, app categories, idps and certificates are cached for name lookups, 0 disables the cache.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.DEFAULT_REQUEST_TIMEOUT / time.Second),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of seconds a single API request may take, retries excluded.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+				Description:  "The proxy used to reach the API. Defaults to the HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"ca_certificates": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The edgerc file path key for the provider.",
+				Optional:    true,
+				Description: "PEM encoded CA certificates trusted in addition to the system roots, e.g. the CA of an egress proxy.",
+			},
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      client.DEFAULT_TLS_MIN_VERSION,
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+				Description:  "The minimum TLS version negotiated with the API.",
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DEFAULT_MAX_IDLE_CONNS,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of idle keep-alive connections kept open to the API.",
+			},
+			"on_create_failure": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      CREATE_FAILURE_TAINT,
+				ValidateFunc: validation.StringInSlice([]string{CREATE_FAILURE_TAINT, CREATE_FAILURE_ROLLBACK}, false),
+				Description:  "What happens to an application whose creation fails after it was created: taint keeps it in the state to be replaced by the next apply, rollback deletes it. The rollback still runs when the creation timed out or was cancelled by the user (Ctrl-C), for at most 2 minutes.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -46,6 +187,7 @@ func Provider() *schema.Provider {
 			"eaa_data_source_appcategories": dataSourceAppCategories(),
 			"eaa_data_source_agents":        dataSourceAgents(),
 			"eaa_data_source_idps":          dataSourceIdps(),
+			"eaa_tls_cipher_suites":         dataSourceTLSCipherSuites(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -55,41 +197,170 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	contractID := d.Get("contractid").(string)
 	accountSwitchKey := d.Get("accountswitchkey").(string)
 
-	edgercPath := d.Get("edgerc").(string)
+	maxRetries := d.Get("max_retries").(int)
+	retryMaxWait := time.Duration(d.Get("retry_max_wait").(int)) * time.Second
+	pageSize := d.Get("page_size").(int)
+	cacheTTL := time.Duration(d.Get("cache_ttl").(int)) * time.Second
 
-	edgerc, err := edgegrid.New(edgegrid.WithFile(edgercPath))
-	if err != nil {
-		return nil, diag.Errorf("%s: %s", ErrInvalidEdgercConfig, err.Error())
+	if contractID == "" {
+		return nil, diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       ErrMissingContractID.Error(),
+			Detail:        fmt.Sprintf("Set contractid in the provider block or the %s environment variable.", ENV_CONTRACT_ID),
+			AttributePath: cty.GetAttrPath("contractid"),
+		}}
 	}
 
-	if err := edgerc.Validate(); err != nil {
-		return nil, diag.FromErr(err)
+	edgerc, diags := edgegridConfig(d)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	httpClient, err := client.NewHTTPClient(client.TransportOptions{
+		Timeout:        time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		ProxyURL:       d.Get("proxy_url").(string),
+		CACertificates: d.Get("ca_certificates").(string),
+		TLSMinVersion:  d.Get("tls_min_version").(string),
+		MaxIdleConns:   d.Get("max_idle_conns").(int),
+	})
+	if err != nil {
+		return nil, diagFromErr(err)
 	}
 
 	logger := hclog.New(&hclog.LoggerOptions{
 		Name:       "eaa_terraform",
-		Level:      hclog.Info,
+		Level:      logLevel(),
 		TimeFormat: time.RFC3339,
 	})
 
 	eaaClient := &client.EaaClient{
-		Client:           http.DefaultClient,
+		Client:           httpClient,
 		ContractID:       contractID,
 		AccountSwitchKey: accountSwitchKey,
 		Signer:           edgerc,
 		Host:             edgerc.Host,
 		Logger:           logger,
+		MaxRetries:       maxRetries,
+		RetryMaxWait:     retryMaxWait,
+		PageSize:         pageSize,
+		CacheTTL:         cacheTTL,
 	}
 
 	// Return the configured client as the provider configuration
-	return eaaClient, nil
+	return &providerMeta{
+		EaaClient:       eaaClient,
+		onCreateFailure: d.Get("on_create_failure").(string),
+	}, nil
+}
+
+// edgegridConfig loads the EdgeGrid credentials from the inline config block,
+// or from the config_section of the edgerc file when the block is not set
+func edgegridConfig(d *schema.ResourceData) (*edgegrid.Config, diag.Diagnostics) {
+	var edgerc *edgegrid.Config
+	if inline, ok := d.Get("config").([]interface{}); ok && len(inline) > 0 && inline[0] != nil {
+		creds := inline[0].(map[string]interface{})
+		edgerc = &edgegrid.Config{
+			Host:         creds["host"].(string),
+			ClientToken:  creds["client_token"].(string),
+			ClientSecret: creds["client_secret"].(string),
+			AccessToken:  creds["access_token"].(string),
+			MaxBody:      edgegrid.MaxBodySize,
+		}
+	} else {
+		edgercPath := d.Get("edgerc").(string)
+		if edgercPath == "" {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  ErrMissingCredentials.Error(),
+				Detail:   fmt.Sprintf("Set edgerc, the %s environment variable or an inline config block.", ENV_EDGERC),
+			}}
+		}
+		section := d.Get("config_section").(string)
+
+		var err error
+		edgerc, err = edgegrid.New(edgegrid.WithFile(edgercPath), edgegrid.WithSection(section))
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       ErrInvalidEdgercConfig.Error(),
+				Detail:        fmt.Sprintf("Unable to read section %q of %s: %s", section, edgercPath, err),
+				AttributePath: cty.GetAttrPath("edgerc"),
+			}}
+		}
+	}
+
+	if err := edgerc.Validate(); err != nil {
+		return nil, diagFromErr(err)
+	}
+	return edgerc, nil
+}
+
+// logLevel follows TF_LOG_PROVIDER, then TF_LOG, the same way Terraform filters provider logs.
+// Request and response bodies are only logged at TRACE, with secrets redacted.
+func logLevel() hclog.Level {
+	for _, env := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if strings.EqualFold(value, "JSON") {
+			return hclog.Trace
+		}
+		if level := hclog.LevelFromString(value); level != hclog.NoLevel {
+			return level
+		}
+	}
+	return hclog.Info
 }
 
 func Client(meta interface{}) (*client.EaaClient, error) {
-	eaaClient, ok := meta.(*client.EaaClient)
+	m, ok := meta.(*providerMeta)
 	if !ok {
 		return nil, fmt.Errorf("Invalid client")
 	}
+	return m.EaaClient, nil
+}
 
-	return eaaClient, nil
+// onCreateFailure returns the on_create_failure setting of the provider, taint when it is not set
+func onCreateFailure(meta interface{}) string {
+	if m, ok := meta.(*providerMeta); ok && m.onCreateFailure != "" {
+		return m.onCreateFailure
+	}
+	return CREATE_FAILURE_TAINT
+}
+
+// diagFromErr converts err into diagnostics.
+// API errors also report the HTTP status, the request and the problemId to quote to support.
+func diagFromErr(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	detail := fmt.Sprintf("HTTP %d", apiErr.StatusCode)
+	if apiErr.Method != "" {
+		detail += fmt.Sprintf(" from %s %s", apiErr.Method, apiErr.URL)
+	}
+	if apiErr.Title != "" {
+		detail += fmt.Sprintf("\ntitle: %s", apiErr.Title)
+	}
+	if apiErr.Type != "" {
+		detail += fmt.Sprintf("\ntype: %s", apiErr.Type)
+	}
+	if apiErr.Instance != "" {
+		detail += fmt.Sprintf("\ninstance: %s", apiErr.Instance)
+	}
+	if apiErr.ProblemID != "" {
+		detail += fmt.Sprintf("\nproblemId: %s", apiErr.ProblemID)
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   detail,
+		},
+	}
 }
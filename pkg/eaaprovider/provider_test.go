@@ -1,7 +1,19 @@
 package eaaprovider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+	"github.com/hashicorp/go-hclog"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
@@ -17,3 +29,174 @@ func init() {
 		},
 	}
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProviderCredentialsConflict(t *testing.T) {
+	inline := []interface{}{map[string]interface{}{
+		"host":          "akab-inline.luna.akamaiapis.net",
+		"client_token":  "akab-client-token",
+		"client_secret": "client-secret",
+		"access_token":  "akab-access-token",
+	}}
+	tests := map[string]struct {
+		raw      map[string]interface{}
+		conflict bool
+	}{
+		"inline config":             {raw: map[string]interface{}{"config": inline}},
+		"edgerc":                    {raw: map[string]interface{}{"edgerc": ".edgerc", "config_section": "ci"}},
+		"inline config and edgerc":  {raw: map[string]interface{}{"config": inline, "edgerc": ".edgerc"}, conflict: true},
+		"inline config and section": {raw: map[string]interface{}{"config": inline, "config_section": "ci"}, conflict: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(tt.raw))
+			if diags.HasError() != tt.conflict {
+				t.Errorf("diags = %v, want a conflict: %t", diags, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestDiagFromErr(t *testing.T) {
+	apiErr := &client.APIError{
+		ErrorResponse: client.ErrorResponse{Detail: "not found", ProblemID: "abc-123"},
+		StatusCode:    http.StatusNotFound,
+		Method:        http.MethodGet,
+		URL:           "https://host/crux/v1/mgmt-pop/apps/uuid",
+		Err:           ErrGetApp,
+	}
+	diags := diagFromErr(fmt.Errorf("reading app: %w", apiErr))
+	if len(diags) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(diags))
+	}
+	for _, want := range []string{"HTTP 404", "GET https://host/crux/v1/mgmt-pop/apps/uuid", "problemId: abc-123"} {
+		if !strings.Contains(diags[0].Detail, want) {
+			t.Errorf("detail %q does not contain %q", diags[0].Detail, want)
+		}
+	}
+	if diagFromErr(nil) != nil {
+		t.Error("expected no diagnostics for a nil error")
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := []struct {
+		tfLog         string
+		tfLogProvider string
+		want          hclog.Level
+	}{
+		{"", "", hclog.Info},
+		{"DEBUG", "", hclog.Debug},
+		{"trace", "", hclog.Trace},
+		{"JSON", "", hclog.Trace},
+		{"ERROR", "DEBUG", hclog.Debug},
+		{"bogus", "", hclog.Info},
+	}
+	for _, tt := range tests {
+		t.Setenv("TF_LOG", tt.tfLog)
+		t.Setenv("TF_LOG_PROVIDER", tt.tfLogProvider)
+		if got := logLevel(); got != tt.want {
+			t.Errorf("TF_LOG=%q TF_LOG_PROVIDER=%q: level = %s, want %s", tt.tfLog, tt.tfLogProvider, got, tt.want)
+		}
+	}
+}
+
+func TestProviderConfigure(t *testing.T) {
+	edgerc := filepath.Join(t.TempDir(), ".edgerc")
+	content := `[default]
+host = akab-default.luna.akamaiapis.net
+client_token = akab-client-token
+client_secret = client-secret
+access_token = akab-access-token
+
+[ci]
+host = akab-ci.luna.akamaiapis.net
+client_token = akab-ci-client-token
+client_secret = ci-client-secret
+access_token = akab-ci-access-token
+`
+	if err := os.WriteFile(edgerc, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		env     map[string]string
+		raw     map[string]interface{}
+		host    string
+		wantErr string
+	}{
+		"edgerc default section": {
+			raw:  map[string]interface{}{"contractid": "1-ABC", "edgerc": edgerc},
+			host: "akab-default.luna.akamaiapis.net",
+		},
+		"edgerc config_section": {
+			raw:  map[string]interface{}{"contractid": "1-ABC", "edgerc": edgerc, "config_section": "ci"},
+			host: "akab-ci.luna.akamaiapis.net",
+		},
+		"environment": {
+			env:  map[string]string{ENV_CONTRACT_ID: "1-ABC", ENV_EDGERC: edgerc, ENV_ACCOUNT_SWITCH_KEY: "ASK-1"},
+			raw:  map[string]interface{}{},
+			host: "akab-default.luna.akamaiapis.net",
+		},
+		"inline config": {
+			raw: map[string]interface{}{
+				"contractid": "1-ABC",
+				"config": []interface{}{map[string]interface{}{
+					"host":          "akab-inline.luna.akamaiapis.net",
+					"client_token":  "akab-client-token",
+					"client_secret": "client-secret",
+					"access_token":  "akab-access-token",
+				}},
+			},
+			host: "akab-inline.luna.akamaiapis.net",
+		},
+		"missing contract": {
+			raw:     map[string]interface{}{"edgerc": edgerc},
+			wantErr: ErrMissingContractID.Error(),
+		},
+		"missing credentials": {
+			raw:     map[string]interface{}{"contractid": "1-ABC"},
+			wantErr: ErrMissingCredentials.Error(),
+		},
+		"unknown section": {
+			raw:     map[string]interface{}{"contractid": "1-ABC", "edgerc": edgerc, "config_section": "prod"},
+			wantErr: ErrInvalidEdgercConfig.Error(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, env := range []string{ENV_CONTRACT_ID, ENV_ACCOUNT_SWITCH_KEY, ENV_EDGERC} {
+				t.Setenv(env, tt.env[env])
+			}
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tt.raw)
+			meta, diags := providerConfigure(context.Background(), d)
+			if tt.wantErr != "" {
+				if !diags.HasError() || diags[0].Summary != tt.wantErr {
+					t.Fatalf("diags = %v, want %q", diags, tt.wantErr)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			ec, err := Client(meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if onCreateFailure(meta) != CREATE_FAILURE_TAINT {
+				t.Errorf("on_create_failure = %s", onCreateFailure(meta))
+			}
+			if ec.Host != tt.host || ec.ContractID != "1-ABC" {
+				t.Errorf("host = %s, contract = %s", ec.Host, ec.ContractID)
+			}
+			if ec.AccountSwitchKey != tt.env[ENV_ACCOUNT_SWITCH_KEY] {
+				t.Errorf("account switch key = %q", ec.AccountSwitchKey)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 
 	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
 
@@ -13,18 +12,24 @@ import (
 )
 
 var (
-	ErrGetApp      = errors.New("app get failed")
+	ErrGetApp      = client.ErrGetApp
 	ErrInvalidData = errors.New("invalid data in schema")
 )
 
 func resourceEaaApplication() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceEaaApplicationCreate,
 		ReadContext:   resourceEaaApplicationRead,
 		UpdateContext: resourceEaaApplicationUpdate,
 		DeleteContext: resourceEaaApplicationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importEaaApplication,
+		},
+		CustomizeDiff: validateApplicationConfig,
+		SchemaVersion: 1,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DEFAULT_DEPLOY_TIMEOUT),
+			Update: schema.DefaultTimeout(DEFAULT_DEPLOY_TIMEOUT),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -37,16 +42,19 @@ func resourceEaaApplication() *schema.Resource {
 				Optional: true,
 			},
 			"app_profile": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(client.APP_PROFILES),
 			},
 			"app_type": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(client.CLIENT_APP_TYPES),
 			},
 			"client_app_mode": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(client.CLIENT_APP_MODES),
 			},
 			"host": {
 				Type:     schema.TypeString,
@@ -58,8 +66,9 @@ func resourceEaaApplication() *schema.Resource {
 			},
 
 			"domain": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateEnum(client.DOMAINS),
 			},
 			"origin_host": {
 				Type:     schema.TypeString,
@@ -182,8 +191,9 @@ func resourceEaaApplication() *schema.Resource {
 				Optional: true,
 			},
 			"cert_type": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateCertType(),
 			},
 			"cert": {
 				Type:     schema.TypeString,
@@ -194,74 +204,25 @@ func resourceEaaApplication() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			"advanced_settings": {
-				Type:     schema.TypeList,
+			"tls_suite_name": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"is_ssl_verification_enabled": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "false",
-						},
-						"edge_authentication_enabled": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "false",
-						},
-						"ignore_cname_resolution": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "false",
-						},
-						"g2o_enabled": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "false",
-						},
-						"g2o_nonce": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"g2o_key": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"x_wapp_read_timeout": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"internal_hostname": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"internal_host_port": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"ip_access_allow": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"wildcard_internal_hostname": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"edge_cookie_key": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"sla_object_url": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-					},
-				},
+				Computed: true,
+			},
+			"allow_weak_tls_cipher": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
+			"advanced_settings": settingsBlockSchema(advancedSettings),
+			"load_balancing":    settingsBlockSchema(loadBalancingSettings),
+			"cors":              settingsBlockSchema(corsSettings),
+			"rdp_settings":      rdpSettingsSchema(),
+			"ssh_settings":      sshSettingsSchema(),
+			"kerberos":          kerberosSchema(),
+			"saml":              samlSchema(),
+			"oidc":              oidcSchema(),
+			"wsfed":             wsfedSchema(),
 			"service": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -361,33 +322,59 @@ func resourceEaaApplication() *schema.Resource {
 			},
 		},
 	}
+	for name, attr := range deploySchema() {
+		r.Schema[name] = attr
+	}
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceEaaApplicationV0Type(),
+			Upgrade: upgradeEaaApplicationStateV0,
+		},
+	}
+	return r
 }
 
 // resourceEaaApplicationCreate function is responsible for creating a new EAA application.
 // constructs the application creation request using data from the schema and creates the application.
 // also handles assigning agents and handling authentication settings if auth_enabled is true.
-// updates the application and deploys it, then sets the resource ID.
+// sets the resource ID as soon as the application exists, then updates and deploys it.
+// a failure after that point is handled by createFailed.
 
 func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	eaaclient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	logger := eaaclient.Logger
 
-	createRequest := client.CreateAppRequest{}
-	err = createRequest.CreateAppRequestFromSchema(ctx, d, eaaclient)
+	createRequest, err := expandCreateAppRequest(d, eaaclient)
 	if err != nil {
 		logger.Error("create Application failed. err ", err)
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	appResp, err := createRequest.CreateApplication(ctx, eaaclient)
 	if err != nil {
 		logger.Error("create Application failed. err ", err)
-		return diag.FromErr(err)
+		return diagFromErr(err)
+	}
+
+	// The application exists from now on, the next apply must not create it again
+	app_uuid_url := appResp.UUIDURL
+	d.SetId(app_uuid_url)
+
+	err = configureNewApplication(ctx, d, eaaclient, appResp)
+	if err != nil {
+		logger.Error("create Application failed. err ", err)
+		return createFailed(ctx, d, m, app_uuid_url, err)
 	}
+	return resourceEaaApplicationRead(ctx, d, m)
+}
 
+// configureNewApplication assigns the agents, the authentication and the services of a new application, then deploys it.
+func configureNewApplication(ctx context.Context, d *schema.ResourceData, eaaclient *client.EaaClient, appResp *client.ApplicationResponse) error {
+	logger := eaaclient.Logger
 	app_uuid_url := appResp.UUIDURL
 	app := client.Application{}
 	app.FromResponse(appResp)
@@ -403,21 +390,22 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 		}
 		err := agents.AssignAgents(ctx, eaaclient)
 		if err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 		logger.Info("create Application: assigning agents succeeded.")
 	}
 
 	appUpdateReq := client.ApplicationUpdateRequest{}
 	appUpdateReq.Application = app
-	err = appUpdateReq.UpdateAppRequestFromSchema(ctx, d, eaaclient)
+	appUpdateReq.AdvancedSettings = appResp.AdvancedSettings
+	err := expandUpdateAppRequest(ctx, d, eaaclient, &appUpdateReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
 	err = appUpdateReq.UpdateApplication(ctx, eaaclient)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
 	auth_enabled := "false"
@@ -430,13 +418,13 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 		if appAuth, ok := d.GetOk("app_authentication"); ok {
 			appAuthList := appAuth.([]interface{})
 			if appAuthList == nil {
-				return diag.FromErr(ErrInvalidData)
+				return ErrInvalidData
 			}
 			if len(appAuthList) > 0 {
 				appAuthenticationMap := appAuthList[0].(map[string]interface{})
 				if appAuthenticationMap == nil {
 					logger.Error("invalid authentication data")
-					return diag.FromErr(ErrInvalidData)
+					return ErrInvalidData
 				}
 
 				// Check if app_idp key is present
@@ -444,7 +432,7 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 					idpData, err := client.GetIdpWithName(ctx, eaaclient, app_idp_name)
 					if err != nil || idpData == nil {
 						logger.Error("get idp with name error, err ", err)
-						return diag.FromErr(err)
+						return err
 					}
 					logger.Info("app.Name: ", app.Name, "app_idp_name: ", app_idp_name, "idpData.UUIDURL: ", idpData.UUIDURL)
 
@@ -452,18 +440,18 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 						App: app_uuid_url,
 						IDP: idpData.UUIDURL,
 					}
-					err = appIdp.AssignIDP(eaaclient)
+					err = appIdp.AssignIDP(ctx, eaaclient)
 					if err != nil {
 						logger.Error("idp assign error err ", err)
-						return diag.FromErr(err)
+						return err
 					}
 					logger.Info("idp assigned successfully, app.Name ", app.Name, "idp ", app_idp_name)
 
 					// check if app_directories are present
 					if appDirs, ok := appAuthenticationMap["app_directories"]; ok {
-						err := idpData.AssignIdpDirectories(ctx, appDirs, app_uuid_url, eaaclient)
+						err := idpData.AssignIdpDirectories(ctx, eaaclient, app_uuid_url, expandDirectoryAssignments(appDirs))
 						if err != nil {
-							return diag.FromErr(err)
+							return err
 						}
 					}
 				}
@@ -472,40 +460,62 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	_, ok := d.Get("service").([]interface{})
 	if ok {
-		aclSrv, err := client.ExtractACLService(ctx, d, eaaclient)
+		aclSrv, err := expandACLService(d, eaaclient)
 		if err != nil {
-			return diag.FromErr(err)
+			return err
 		}
-		appSrv, err := client.GetACLService(eaaclient, app_uuid_url)
+		appSrv, err := client.GetACLService(ctx, eaaclient, app_uuid_url)
 		if err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 		if appSrv.Status != aclSrv.Status {
 			appSrv.Status = aclSrv.Status
-			err := appSrv.EnableService(eaaclient)
+			err := appSrv.EnableService(ctx, eaaclient)
 			if err != nil {
-				return diag.FromErr(err)
+				return err
 			}
 		}
 		if len(aclSrv.ACLRules) > 0 {
 			for _, aclRule := range aclSrv.ACLRules {
 				err := aclRule.CreateAccessRule(ctx, eaaclient, appSrv.UUIDURL)
 				if err != nil {
-					return diag.FromErr(err)
+					return err
 				}
 			}
 		}
 	}
 
-	err = app.DeployApplication(eaaclient)
+	return deployApplication(ctx, d, eaaclient, &app)
+}
 
-	if err != nil {
-		return diag.FromErr(err)
+// createFailed handles a failure after the application was created, according to on_create_failure of the provider.
+// With taint the ID is kept, Terraform taints the resource and the next apply replaces it.
+// With rollback the application is deleted, it stays tainted when the delete fails too.
+func createFailed(ctx context.Context, d *schema.ResourceData, m interface{}, app_uuid_url string, err error) diag.Diagnostics {
+	diags := diagFromErr(err)
+	if onCreateFailure(m) != CREATE_FAILURE_ROLLBACK {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("application %s was created but not fully configured", app_uuid_url),
+			Detail:   "The resource is tainted and the application is replaced by the next apply.",
+		})
+	}
+
+	// the create timeout or a cancel of the user may be what failed, the rollback gets its own context
+	rollbackCtx, cancel := context.WithTimeout(context.Background(), ROLLBACK_TIMEOUT)
+	defer cancel()
+	eaaclient, _ := Client(m)
+	app := client.Application{UUIDURL: app_uuid_url}
+	if delErr := app.DeleteApplication(rollbackCtx, eaaclient); delErr != nil && !client.IsNotFound(delErr) {
+		eaaclient.Logger.Error("rollback of Application failed", "id", app_uuid_url, "error", delErr)
+		return append(diags, diagFromErr(fmt.Errorf("rollback of application %s failed, it is tainted and replaced by the next apply: %w", app_uuid_url, delErr))...)
 	}
-
-	// Set the resource ID
-	d.SetId(app_uuid_url)
-	return resourceEaaApplicationRead(ctx, d, m)
+	d.SetId("")
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("application %s was rolled back", app_uuid_url),
+		Detail:   "The application was deleted after its configuration failed.",
+	})
 }
 
 // resourceEaaApplicationRead function reads an existing EAA application.
@@ -514,19 +524,20 @@ func resourceEaaApplicationCreate(ctx context.Context, d *schema.ResourceData, m
 func resourceEaaApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	id := d.Id()
-	eaaclient := m.(*client.EaaClient)
-	var appResp client.ApplicationDataModel
-
-	apiURL := fmt.Sprintf("%s://%s/%s/%s", client.URL_SCHEME, eaaclient.Host, client.APPS_URL, id)
-
-	getResp, err := eaaclient.SendAPIRequest(apiURL, "GET", nil, &appResp, false)
+	eaaclient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := client.FormatErrorResponse(getResp)
-		getAppErrMsg := fmt.Errorf("%w: %s", ErrGetApp, desc)
-		return diag.FromErr(getAppErrMsg)
+
+	appResp, err := client.GetApplication(ctx, eaaclient, id)
+	if client.IsNotFound(err) {
+		// deleted outside of terraform, removing it from the state plans a new one
+		eaaclient.Logger.Warn("application not found, removing it from the state", "id", id)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diagFromErr(err)
 	}
 	attrs := make(map[string]interface{})
 	attrs["name"] = appResp.Name
@@ -587,9 +598,12 @@ func resourceEaaApplicationRead(ctx context.Context, d *schema.ResourceData, m i
 	if appResp.CName != nil {
 		attrs["cname"] = *appResp.CName
 	}
+	if appResp.TLSSuiteName != nil {
+		attrs["tls_suite_name"] = *appResp.TLSSuiteName
+	}
 
-	if err := client.SetAttrs(d, attrs); err != nil {
-		return diag.FromErr(err)
+	if err := setAttrs(d, attrs); err != nil {
+		return diagFromErr(err)
 	}
 
 	servers := make([]map[string]interface{}, len(appResp.Servers))
@@ -604,7 +618,7 @@ func resourceEaaApplicationRead(ctx context.Context, d *schema.ResourceData, m i
 
 	err = d.Set("servers", servers)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	if client.ClientAppTypeInt(appResp.AppType) == client.APP_TYPE_TUNNEL {
@@ -619,72 +633,115 @@ func resourceEaaApplicationRead(ctx context.Context, d *schema.ResourceData, m i
 		}
 		err = d.Set("tunnel_internal_hosts", tunnelInternalHosts)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
-	advSettings := make([]map[string]interface{}, 1)
+	advSettings, err := flattenSettingsBlock(advancedSettings, &appResp.AdvancedSettings)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("advanced_settings", advSettings)
+	if err != nil {
+		return diagFromErr(err)
+	}
 
-	advSettings[0] = map[string]interface{}{
-		"g2o_enabled":                 appResp.AdvancedSettings.G2OEnabled,
-		"g2o_nonce":                   appResp.AdvancedSettings.G2ONonce,
-		"g2o_key":                     appResp.AdvancedSettings.G2OKey,
-		"is_ssl_verification_enabled": appResp.AdvancedSettings.IsSSLVerificationEnabled,
-		"ignore_cname_resolution":     appResp.AdvancedSettings.IgnoreCnameResolution,
-		"edge_authentication_enabled": appResp.AdvancedSettings.EdgeAuthenticationEnabled,
-		"edge_cookie_key":             appResp.AdvancedSettings.EdgeCookieKey,
-		"sla_object_url":              appResp.AdvancedSettings.SlaObjectUrl,
+	loadBalancing, err := flattenSettingsBlock(loadBalancingSettings, &appResp.AdvancedSettings)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("load_balancing", loadBalancing)
+	if err != nil {
+		return diagFromErr(err)
 	}
 
-	if client.ClientAppTypeInt(appResp.AppType) == client.APP_TYPE_TUNNEL {
-		advSettings[0]["x_wapp_read_timeout"] = appResp.AdvancedSettings.XWappReadTimeout
-		advSettings[0]["internal_hostname"] = appResp.AdvancedSettings.InternalHostname
-		advSettings[0]["internal_host_port"] = appResp.AdvancedSettings.InternalHostPort
-		advSettings[0]["wildcard_internal_hostname"] = appResp.AdvancedSettings.WildcardInternalHostname
-		advSettings[0]["ip_access_allow"] = appResp.AdvancedSettings.IPAccessAllow
+	cors, err := flattenSettingsBlock(corsSettings, &appResp.AdvancedSettings)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("cors", cors)
+	if err != nil {
+		return diagFromErr(err)
 	}
 
-	err = d.Set("advanced_settings", advSettings)
+	rdp, err := flattenRDPSettings(appResp)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("rdp_settings", rdp)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	appAgents, err := appResp.Application.GetAppAgents(eaaclient)
+	ssh, err := flattenSSHSettings(d, appResp)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("ssh_settings", ssh)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	kerberos, err := flattenKerberos(d, &appResp.AdvancedSettings)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	err = d.Set("kerberos", kerberos)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = d.Set("saml", flattenSAML(appResp))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = d.Set("oidc", flattenOIDC(d, appResp))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	err = d.Set("wsfed", flattenWSFed(appResp))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	appAgents, err := appResp.Application.GetAppAgents(ctx, eaaclient)
 	if err == nil {
 		err = d.Set("agents", appAgents)
 		if err != nil {
-			return diag.FromErr(err) // Return the error wrapped in a diag.Diagnostic
+			return diagFromErr(err) // Return the error wrapped in a diag.Diagnostic
 		}
 	}
 	if appResp.AuthEnabled == "true" {
-		appAuthData, err := appResp.Application.CreateAppAuthenticationStruct(eaaclient)
+		appAuth, err := appResp.Application.GetAppAuthentication(ctx, eaaclient)
 		if err == nil {
-			err = d.Set("app_authentication", appAuthData)
+			err = d.Set("app_authentication", flattenAppAuthentication(appAuth))
 			if err != nil {
-				return diag.FromErr(err) // Return the error wrapped in a diag.Diagnostic
+				return diagFromErr(err) // Return the error wrapped in a diag.Diagnostic
 			}
 		}
 	}
 
 	if appResp.Cert != nil {
-		appCertData, err := client.GetCertificate(eaaclient, *appResp.Cert)
+		appCertData, err := client.GetCertificate(ctx, eaaclient, *appResp.Cert)
 		if err == nil {
 			err = d.Set("cert", appCertData.Cert)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 		}
 	}
 
-	aclSrv, err := client.GetACLService(eaaclient, appResp.UUIDURL)
+	aclSrv, err := client.GetACLService(ctx, eaaclient, appResp.UUIDURL)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	} else {
-		appSvcData, err := aclSrv.CreateAppServiceStruct(eaaclient)
+		appSvcData, err := flattenAppService(ctx, eaaclient, aclSrv)
 		if err == nil && appSvcData != nil {
 			err = d.Set("service", appSvcData)
 			if err != nil {
-				return diag.FromErr(err) // Return the error wrapped in a diag.Diagnostic
+				return diagFromErr(err) // Return the error wrapped in a diag.Diagnostic
 			}
 		}
 	}
@@ -697,36 +754,37 @@ func resourceEaaApplicationRead(ctx context.Context, d *schema.ResourceData, m i
 // then calls the read function to ensure the updated data is correctly populated in the schema.
 
 func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChangesExcept(localDeployAttrs...) {
+		return nil
+	}
+
 	// Set the resource ID
 	id := d.Id()
-	eaaclient := m.(*client.EaaClient)
-	var appResp client.Application
-
-	apiURL := fmt.Sprintf("%s://%s/%s/%s", client.URL_SCHEME, eaaclient.Host, client.APPS_URL, id)
-
-	getResp, err := eaaclient.SendAPIRequest(apiURL, "GET", nil, &appResp, false)
+	eaaclient, err := Client(m)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
-		desc, _ := client.FormatErrorResponse(getResp)
-		getAppErrMsg := fmt.Errorf("%w: %s", ErrGetApp, desc)
-		return diag.FromErr(getAppErrMsg)
+
+	appData, err := client.GetApplication(ctx, eaaclient, id)
+	if err != nil {
+		return diagFromErr(err)
 	}
+	appResp := appData.Application
 
 	appUpdateReq := client.ApplicationUpdateRequest{}
 	appUpdateReq.Application = appResp
-	err = appUpdateReq.UpdateAppRequestFromSchema(ctx, d, eaaclient)
+	appUpdateReq.AdvancedSettings = appData.AdvancedSettings
+	err = expandUpdateAppRequest(ctx, d, eaaclient, &appUpdateReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	err = appUpdateReq.UpdateApplication(ctx, eaaclient)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	currAgents, err := appResp.GetAppAgents(eaaclient)
+	currAgents, err := appResp.GetAppAgents(ctx, eaaclient)
 	if err == nil {
 		if agentsRaw, ok := d.GetOk("agents"); ok {
 			agentList := agentsRaw.([]interface{})
@@ -746,7 +804,7 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 				agents.AgentNames = append(agents.AgentNames, agentsToAssign...)
 				err := agents.AssignAgents(ctx, eaaclient)
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 			}
 			if len(agentsToUnassign) > 0 {
@@ -756,7 +814,7 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 
 				err := agents.UnAssignAgents(ctx, eaaclient)
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 			}
 		}
@@ -771,30 +829,30 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 		if auth_enabled == "true" {
 			if appAuth, ok := d.GetOk("app_authentication"); ok {
 				app_uuid_url := id
-				appIDPMembership, err := appResp.GetAppIdpMembership(eaaclient)
+				appIDPMembership, err := appResp.GetAppIdpMembership(ctx, eaaclient)
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 				if appIDPMembership != nil {
 					appIdp := client.AppIdp{
 						App: app_uuid_url,
 						IDP: appIDPMembership.UUIDURL,
 					}
-					err = appIdp.UnAssignIDP(eaaclient)
+					err = appIdp.UnAssignIDP(ctx, eaaclient)
 					if err != nil {
 						eaaclient.Logger.Error("idp unassign error err ", err)
-						return diag.FromErr(err)
+						return diagFromErr(err)
 					}
 				}
 				appAuthList := appAuth.([]interface{})
 				if appAuthList == nil {
-					return diag.FromErr(ErrInvalidData)
+					return diagFromErr(ErrInvalidData)
 				}
 				if len(appAuthList) > 0 {
 					appAuthenticationMap := appAuthList[0].(map[string]interface{})
 					if appAuthenticationMap == nil {
 						eaaclient.Logger.Error("invalid authentication data")
-						return diag.FromErr(ErrInvalidData)
+						return diagFromErr(ErrInvalidData)
 					}
 
 					// Check if app_idp key is present
@@ -802,24 +860,24 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 						idpData, err := client.GetIdpWithName(ctx, eaaclient, app_idp_name)
 						if err != nil || idpData == nil {
 							eaaclient.Logger.Error("get idp with name error, err ", err)
-							return diag.FromErr(err)
+							return diagFromErr(err)
 						}
 
 						appIdp := client.AppIdp{
 							App: app_uuid_url,
 							IDP: idpData.UUIDURL,
 						}
-						err = appIdp.AssignIDP(eaaclient)
+						err = appIdp.AssignIDP(ctx, eaaclient)
 						if err != nil {
 							eaaclient.Logger.Error("idp assign error err ", err)
-							return diag.FromErr(err)
+							return diagFromErr(err)
 						}
 
 						// check if app_directories are present
 						if appDirs, ok := appAuthenticationMap["app_directories"]; ok {
-							err := idpData.AssignIdpDirectories(ctx, appDirs, app_uuid_url, eaaclient)
+							err := idpData.AssignIdpDirectories(ctx, eaaclient, app_uuid_url, expandDirectoryAssignments(appDirs))
 							if err != nil {
-								return diag.FromErr(err)
+								return diagFromErr(err)
 							}
 						}
 					}
@@ -835,28 +893,28 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 
 		if len(services) > 0 {
 			app_uuid_url := appResp.UUIDURL
-			appSrv, err := client.GetACLService(eaaclient, app_uuid_url)
+			appSrv, err := client.GetACLService(ctx, eaaclient, app_uuid_url)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 
-			aclSrv, err := client.ExtractACLService(ctx, d, eaaclient)
+			aclSrv, err := expandACLService(d, eaaclient)
 			if err != nil {
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 
 			if appSrv.Status != aclSrv.Status {
 				appSrv.Status = aclSrv.Status
-				err := appSrv.EnableService(eaaclient)
+				err := appSrv.EnableService(ctx, eaaclient)
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 			}
 			if d.HasChange("service.0.access_rule") {
 				// Fetch existing rules
-				existingACLResponse, err := client.GetAccessControlRules(eaaclient, appSrv.UUIDURL)
+				existingACLResponse, err := client.GetAccessControlRules(ctx, eaaclient, appSrv.UUIDURL)
 				if err != nil {
-					return diag.FromErr(err)
+					return diagFromErr(err)
 				}
 				existingRulesMap := make(map[string]client.AccessRule)
 				for _, rule := range existingACLResponse.ACLRules {
@@ -873,7 +931,7 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 				for name, existingRule := range existingRulesMap {
 					if _, exists := newRulesMap[name]; !exists {
 						if err := existingRule.DeleteAccessRule(ctx, eaaclient, appSrv.UUIDURL); err != nil {
-							return diag.FromErr(err)
+							return diagFromErr(err)
 						}
 					}
 				}
@@ -884,13 +942,13 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 						if !existingRule.IsEqual(newRule) {
 							newRule.UUID_URL = existingRule.UUID_URL
 							if err := newRule.ModifyAccessRule(ctx, eaaclient, appSrv.UUIDURL); err != nil {
-								return diag.FromErr(err)
+								return diagFromErr(err)
 							}
 						}
 					} else {
 						// Create new rule
 						if err := newRule.CreateAccessRule(ctx, eaaclient, appSrv.UUIDURL); err != nil {
-							return diag.FromErr(err)
+							return diagFromErr(err)
 						}
 					}
 				}
@@ -898,25 +956,28 @@ func resourceEaaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	err = appUpdateReq.Application.DeployApplication(eaaclient)
+	err = deployApplication(ctx, d, eaaclient, &appUpdateReq.Application)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	return resourceEaaApplicationRead(ctx, d, m)
 }
 
 // resourceEaaApplicationDelete function deletes an existing EAA application.
-// sends a delete request to the EAA client to remove the application.
+// sends a delete request to the EAA client to remove the application, an application already deleted is not an error.
 func resourceEaaApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Read the resource ID from d
 	id := d.Id()
-	eaaclient := m.(*client.EaaClient)
+	eaaclient, err := Client(m)
+	if err != nil {
+		return diagFromErr(err)
+	}
 
 	// Send the delete application REST endpoint
-	err := eaaclient.SendDeleteApplicationEndpoint(id)
-	if err != nil {
-		return diag.FromErr(err)
+	err = eaaclient.SendDeleteApplicationEndpoint(ctx, id)
+	if err != nil && !client.IsNotFound(err) {
+		return diagFromErr(err)
 	}
 
 	// Set the resource ID to mark it as deleted
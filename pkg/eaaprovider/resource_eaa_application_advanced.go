@@ -0,0 +1,391 @@
+package eaaprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type settingKind int
+
+const (
+	// settingString is sent as is
+	settingString settingKind = iota
+	// settingBool is a bool sent as "true"/"false"
+	settingBool
+	// settingSwitch is a bool sent as "on"/"off"
+	settingSwitch
+	// settingInt is a number sent as a string
+	settingInt
+	// settingList is a list of strings sent separated by spaces, "*" is sent as "unbounded"
+	settingList
+)
+
+// settingAny is the list item standing for any value, e.g. any origin
+const settingAny = "*"
+
+// advancedSetting describes an attribute of a block backed by client.AdvancedSettings_Complete, e.g. advanced_settings.
+// Name is the json name of the field, Attr the attribute name when it differs from Name.
+type advancedSetting struct {
+	Name        string
+	Attr        string
+	Kind        settingKind
+	Description string
+	// Default is sent on every create and update, settings without one keep the value chosen by EAA until configured
+	Default interface{}
+	// Generated settings are set by the API, e.g. the G2O key, and never sent from the configuration
+	Generated bool
+	Validate  schema.SchemaValidateDiagFunc
+}
+
+var (
+	validatePort     = validation.ToDiagFunc(validation.IsPortNumber)
+	validatePositive = validation.ToDiagFunc(validation.IntAtLeast(0))
+)
+
+// advancedSettings lists the settings of client.AdvancedSettings_Complete exposed in the advanced_settings block.
+// The settings of a single feature, health checks, CORS, the RDP/SSH profiles and Kerberos, only make sense
+// together and are left out of this flat list.
+var advancedSettings = []advancedSetting{
+	// origin connection
+	{Name: "is_ssl_verification_enabled", Kind: settingBool, Default: false, Description: "Whether the connector validates the certificate of the origin server"},
+	{Name: "server_cert_validate", Kind: settingBool, Description: "Whether EAA validates the certificate of the origin server"},
+	{Name: "ignore_cname_resolution", Kind: settingBool, Default: false, Description: "Whether the application is reached through the Akamai CDN instead of its CNAME"},
+	{Name: "x_wapp_read_timeout", Kind: settingInt, Validate: validatePositive, Description: "Read timeout of the application in seconds, required for tunnel apps"},
+	{Name: "app_server_read_timeout", Kind: settingInt, Validate: validatePositive, Description: "Read timeout of the origin server in seconds"},
+	{Name: "internal_hostname", Kind: settingString, Description: "Internal hostname of the application"},
+	{Name: "internal_host_port", Kind: settingInt, Validate: validatePort, Description: "Internal port of the application"},
+	{Name: "wildcard_internal_hostname", Kind: settingBool, Description: "Whether the internal hostname is a wildcard"},
+	{Name: "ip_access_allow", Kind: settingBool, Description: "Whether the application can be reached by its IP address"},
+	{Name: "force_ip_route", Kind: settingBool, Description: "Whether the traffic is routed by IP address"},
+	{Name: "proxy_buffer_size_kb", Kind: settingInt, Validate: validatePositive, Description: "Size of the proxy buffers in KB"},
+	{Name: "keepalive_enable", Kind: settingBool, Description: "Whether the connections to the origin are kept alive"},
+	{Name: "keepalive_connection_pool", Kind: settingInt, Validate: validatePositive, Description: "Number of keep-alive connections to the origin"},
+	{Name: "keepalive_timeout", Kind: settingInt, Validate: validatePositive, Description: "Keep-alive timeout in seconds"},
+	{Name: "keyed_keepalive_enable", Kind: settingBool, Description: "Whether the keep-alive connections are keyed per user"},
+	{Name: "idle_conn_floor", Kind: settingInt, Validate: validatePositive, Description: "Minimum number of idle connections to the origin"},
+	{Name: "idle_conn_ceil", Kind: settingInt, Validate: validatePositive, Description: "Maximum number of idle connections to the origin"},
+	{Name: "idle_conn_step", Kind: settingInt, Validate: validatePositive, Description: "Number of idle connections opened or closed at once"},
+	{Name: "idle_close_time_seconds", Kind: settingInt, Validate: validatePositive, Description: "Time after which idle connections are closed, in seconds"},
+	{Name: "offload_onpremise_traffic", Kind: settingBool, Description: "Whether on-premise users reach the application directly"},
+	{Name: "acceleration", Kind: settingBool, Description: "Whether the traffic is accelerated by the Akamai platform"},
+	{Name: "sticky_agent", Kind: settingBool, Description: "Whether a user stays on the same connector"},
+	{Name: "saas_enabled", Kind: settingBool, Description: "Whether the application is a SaaS application"},
+
+	// authentication and session
+	{Name: "login_url", Kind: settingString, Description: "URL users are sent to for logging in"},
+	{Name: "logout_url", Kind: settingString, Description: "URL users are sent to after logging out"},
+	{Name: "login_timeout", Kind: settingInt, Validate: validatePositive, Description: "Login timeout in minutes"},
+	{Name: "wapp_auth", Kind: settingString, Validate: validation.ToDiagFunc(validation.StringInSlice([]string{"form", "basic", "basic_cookie", "jwt", "certonly"}, false)), Description: "How users authenticate to EAA: form, basic, basic_cookie, jwt or certonly"},
+	{Name: "sso", Kind: settingBool, Description: "Whether single sign-on is enabled"},
+	{Name: "mfa", Kind: settingString, Description: "Multi-factor authentication mode of the application"},
+	{Name: "force_mfa", Kind: settingSwitch, Description: "Whether multi-factor authentication is always required"},
+	{Name: "ignore_bypass_mfa", Kind: settingSwitch, Description: "Whether the MFA bypass of the directory is ignored"},
+	{Name: "idp_idle_expiry", Kind: settingInt, Validate: validatePositive, Description: "Idle session expiry of the IDP in seconds"},
+	{Name: "idp_max_expiry", Kind: settingInt, Validate: validatePositive, Description: "Maximum session duration of the IDP in seconds"},
+	{Name: "client_cert_auth", Kind: settingBool, Description: "Whether users authenticate with a client certificate"},
+	{Name: "client_cert_user_param", Kind: settingString, Description: "Certificate field holding the user name"},
+	{Name: "app_client_cert_auth", Kind: settingBool, Description: "Whether the application requests a client certificate"},
+	{Name: "preauth_consent", Kind: settingBool, Description: "Whether users accept a consent page before logging in"},
+	{Name: "preauth_enforce_url", Kind: settingString, Description: "URL of the pre-authentication consent page"},
+	{Name: "sentry_redirect_401", Kind: settingSwitch, Description: "Whether unauthenticated requests are redirected to the login page"},
+	{Name: "mdc_enable", Kind: settingBool, Description: "Whether multi-domain cookies are enabled"},
+
+	// cookies
+	{Name: "cookie_domain", Kind: settingString, Description: "Domain of the EAA session cookie"},
+	{Name: "app_cookie_domain", Kind: settingString, Description: "Domain of the application cookies"},
+	{Name: "external_cookie_domain", Kind: settingString, Description: "External domain of the application cookies"},
+	{Name: "app_auth_domain", Kind: settingString, Description: "Authentication domain of the application"},
+	{Name: "http_only_cookie", Kind: settingBool, Description: "Whether the session cookie is HttpOnly"},
+
+	// request and response handling
+	{Name: "app_location", Kind: settingString, Description: "Path the application is served from"},
+	{Name: "request_parameters", Kind: settingString, Description: "Parameters added to the requests sent to the origin"},
+	{Name: "request_body_rewrite", Kind: settingBool, Description: "Whether request bodies are rewritten"},
+	{Name: "domain_exception_list", Kind: settingString, Description: "Domains that are not rewritten"},
+	{Name: "inject_ajax_javascript", Kind: settingSwitch, Description: "Whether the AJAX helper script is injected in the pages"},
+	{Name: "proxy_disable_clipboard", Kind: settingSwitch, Description: "Whether the clipboard is disabled in the browser"},
+	{Name: "https_sslv3", Kind: settingBool, Description: "Whether SSLv3 is accepted"},
+	{Name: "spdy_enabled", Kind: settingBool, Description: "Whether SPDY/HTTP2 is enabled"},
+	{Name: "websocket_enabled", Kind: settingBool, Description: "Whether WebSocket connections are allowed"},
+	{Name: "hsts_age", Kind: settingInt, Validate: validatePositive, Description: "max-age of the Strict-Transport-Security header in seconds"},
+	{Name: "hidden_app", Kind: settingBool, Description: "Whether the application is hidden from the user portal"},
+	{Name: "logging_enabled", Kind: settingBool, Description: "Whether access logs are collected"},
+	{Name: "single_host_enable", Kind: settingBool, Description: "Whether the application is served from a single host"},
+	{Name: "single_host_fqdn", Kind: settingString, Description: "FQDN of the single host"},
+	{Name: "single_host_path", Kind: settingString, Description: "Path of the application on the single host"},
+	{Name: "single_host_content_rw", Kind: settingBool, Description: "Whether the content is rewritten for the single host"},
+	{Name: "single_host_cookie_domain", Kind: settingBool, Description: "Whether cookies are scoped to the single host"},
+
+	// rate limiting
+	{Name: "rate_limit", Kind: settingSwitch, Description: "Whether requests to the origin are rate limited"},
+	{Name: "authenticated_server_request_limit", Kind: settingInt, Validate: validatePositive, Description: "Requests per second allowed for an authenticated user"},
+	{Name: "anonymous_server_request_limit", Kind: settingInt, Validate: validatePositive, Description: "Requests per second allowed for an anonymous user"},
+	{Name: "authenticated_server_conn_limit", Kind: settingInt, Validate: validatePositive, Description: "Connections allowed for an authenticated user"},
+	{Name: "anonymous_server_conn_limit", Kind: settingInt, Validate: validatePositive, Description: "Connections allowed for an anonymous user"},
+	{Name: "server_request_burst", Kind: settingInt, Validate: validatePositive, Description: "Requests allowed above the limit in a burst"},
+
+	// edge enforcement
+	{Name: "g2o_enabled", Kind: settingBool, Default: false, Description: "Whether G2O is enabled, used with Akamai Edge Enforcement"},
+	{Name: "g2o_nonce", Kind: settingString, Generated: true, Description: "G2O nonce generated by EAA"},
+	{Name: "g2o_key", Kind: settingString, Generated: true, Description: "G2O key generated by EAA"},
+	{Name: "edge_authentication_enabled", Kind: settingBool, Default: false, Description: "Whether the edge authentication cookie is enabled"},
+	{Name: "edge_cookie_key", Kind: settingString, Generated: true, Description: "Edge authentication cookie key generated by EAA"},
+	{Name: "sla_object_url", Kind: settingString, Generated: true, Description: "SLA object URL generated by EAA"},
+}
+
+func (s advancedSetting) attr() string {
+	if s.Attr != "" {
+		return s.Attr
+	}
+	return s.Name
+}
+
+func (s advancedSetting) schema() *schema.Schema {
+	attr := &schema.Schema{
+		Optional:         true,
+		Description:      s.Description,
+		ValidateDiagFunc: s.Validate,
+	}
+	switch s.Kind {
+	case settingBool, settingSwitch:
+		attr.Type = schema.TypeBool
+	case settingInt:
+		attr.Type = schema.TypeInt
+	case settingList:
+		// validation of lists applies to their items
+		attr.Type = schema.TypeList
+		attr.Elem = &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: s.Validate}
+		attr.ValidateDiagFunc = nil
+	default:
+		attr.Type = schema.TypeString
+	}
+	if s.Default != nil {
+		attr.Default = s.Default
+	} else {
+		attr.Computed = true
+	}
+	return attr
+}
+
+// toAPI converts a value of the schema to the string sent to the API
+func (s advancedSetting) toAPI(value interface{}) string {
+	switch s.Kind {
+	case settingBool:
+		v, _ := value.(bool)
+		return strconv.FormatBool(v)
+	case settingSwitch:
+		if v, _ := value.(bool); v {
+			return client.STR_ON
+		}
+		return client.STR_OFF
+	case settingInt:
+		v, _ := value.(int)
+		return strconv.Itoa(v)
+	case settingList:
+		items, _ := value.([]interface{})
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			if v, _ := item.(string); v == settingAny {
+				return client.STR_UNBOUNDED
+			} else if v != "" {
+				values = append(values, v)
+			}
+		}
+		return strings.Join(values, " ")
+	default:
+		v, _ := value.(string)
+		return v
+	}
+}
+
+// fromAPI converts a value returned by the API to the schema type, unset values become the zero value
+func (s advancedSetting) fromAPI(value string, ok bool) (interface{}, error) {
+	switch s.Kind {
+	case settingBool, settingSwitch:
+		if !ok {
+			return false, nil
+		}
+		switch value {
+		case client.STR_ON:
+			return true, nil
+		case client.STR_OFF:
+			return false, nil
+		}
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: advanced setting %s is %q, want a bool", ErrInvalidData, s.Name, value)
+		}
+		return v, nil
+	case settingInt:
+		if !ok {
+			return 0, nil
+		}
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: advanced setting %s is %q, want a number", ErrInvalidData, s.Name, value)
+		}
+		return v, nil
+	case settingList:
+		if value == client.STR_UNBOUNDED {
+			return []interface{}{settingAny}, nil
+		}
+		// commas are accepted as separators as well
+		fields := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		items := make([]interface{}, len(fields))
+		for i, field := range fields {
+			items[i] = field
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
+
+// settingsBlockSchema returns the schema of a block holding settings, values that are not configured are computed
+func settingsBlockSchema(settings []advancedSetting) *schema.Schema {
+	attrs := make(map[string]*schema.Schema, len(settings))
+	for _, setting := range settings {
+		attrs[setting.attr()] = setting.schema()
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: attrs,
+		},
+	}
+}
+
+// settingConfigured reports whether attr is set in block in the configuration, config is the raw configuration.
+// Unlike GetOk it tells an explicit false or 0 apart from an unset attribute.
+func settingConfigured(config cty.Value, block, attr string) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	list := config.GetAttr(block)
+	if list.IsNull() || !list.IsKnown() || list.LengthInt() == 0 {
+		return false
+	}
+	return !list.Index(cty.NumberIntVal(0)).GetAttr(attr).IsNull()
+}
+
+// expandSettingsBlock copies the configured settings of block into target,
+// which holds the current settings of the application so the unset ones are left as they are
+func expandSettingsBlock(d *schema.ResourceData, block string, settings []advancedSetting, target *client.AdvancedSettings_Complete) error {
+	list, ok := d.Get(block).([]interface{})
+	if !ok || len(list) == 0 {
+		return nil
+	}
+	data, ok := list[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, setting := range settings {
+		if setting.Generated {
+			continue
+		}
+		_, set := d.GetOk(block + ".0." + setting.attr())
+		if !set && setting.Default == nil && !settingConfigured(d.GetRawConfig(), block, setting.attr()) {
+			continue
+		}
+		if err := target.Set(setting.Name, setting.toAPI(data[setting.attr()])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenSettingsBlock returns a block holding settings from the settings returned by the API
+func flattenSettingsBlock(settings []advancedSetting, source *client.AdvancedSettings_Complete) ([]interface{}, error) {
+	data := make(map[string]interface{}, len(settings))
+	for _, setting := range settings {
+		value, err := setting.fromAPI(source.Get(setting.Name))
+		if err != nil {
+			return nil, err
+		}
+		data[setting.attr()] = value
+	}
+	return []interface{}{data}, nil
+}
+
+// hashSecret is the StateFunc of the secrets, the state never holds their value
+func hashSecret(v interface{}) string {
+	value, _ := v.(string)
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// secretSchema returns a sensitive attribute of which the state only keeps the SHA-256,
+// it is sent to EAA again only when the configured value changes
+func secretSchema(description string, validate schema.SchemaValidateDiagFunc) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        true,
+		StateFunc:        hashSecret,
+		ValidateDiagFunc: validate,
+		Description:      description,
+	}
+}
+
+// changedSecret returns the configured value of the secret at key when it changed in this apply
+func changedSecret(d *schema.ResourceData, key string) (string, bool) {
+	if !d.HasChange(key) {
+		return "", false
+	}
+	value, _ := d.Get(key).(string)
+	return value, true
+}
+
+// secretState returns the value of the secret at key to store in the state.
+// d.Get returns the configured value when it changed in this apply and the stored hash otherwise.
+func secretState(d *schema.ResourceData, key string, stateFunc schema.SchemaStateFunc) interface{} {
+	if d.HasChange(key) {
+		return stateFunc(d.Get(key))
+	}
+	return d.Get(key)
+}
+
+// upgradeEaaApplicationStateV0 converts the "true"/"false" and number strings of the advanced settings to their types.
+// Values that cannot be converted are dropped and read again from the API on the next refresh.
+func upgradeEaaApplicationStateV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	block, ok := rawState["advanced_settings"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+	for _, item := range block {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, setting := range advancedSettings {
+			raw, ok := data[setting.Name].(string)
+			if !ok || setting.Kind == settingString {
+				continue
+			}
+			value, err := setting.fromAPI(raw, raw != "")
+			if err != nil {
+				delete(data, setting.Name)
+				continue
+			}
+			data[setting.Name] = value
+		}
+	}
+	return rawState, nil
+}
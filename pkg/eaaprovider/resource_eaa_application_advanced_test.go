@@ -0,0 +1,133 @@
+package eaaprovider
+
+import (
+	"context"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAdvancedSettingsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, map[string]interface{}{
+		"name": "app",
+		"advanced_settings": []interface{}{
+			map[string]interface{}{
+				"websocket_enabled":  true,
+				"login_timeout":      15,
+				"force_mfa":          true,
+				"login_url":          "https://login.example.com",
+				"internal_host_port": 8080,
+			},
+		},
+	})
+
+	// the current settings of the application, the ones that are not configured must be kept
+	current := client.AdvancedSettings_Complete{HiddenApp: "true", RateLimit: client.STR_ON, HSTSage: "300"}
+	if err := expandSettingsBlock(d, "advanced_settings", advancedSettings, &current); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{
+		"websocket_enabled":  "true",
+		"login_timeout":      "15",
+		"force_mfa":          client.STR_ON,
+		"login_url":          "https://login.example.com",
+		"internal_host_port": "8080",
+		"g2o_enabled":        client.STR_FALSE,
+		"hidden_app":         "true",
+		"rate_limit":         client.STR_ON,
+		"hsts_age":           "300",
+	}
+	for key, value := range want {
+		if got, _ := current.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if _, ok := current.Get("sso"); ok {
+		t.Error("sso is sent although it is not configured")
+	}
+
+	flattened, err := flattenSettingsBlock(advancedSettings, &current)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data := flattened[0].(map[string]interface{})
+	for key, value := range map[string]interface{}{
+		"websocket_enabled": true,
+		"login_timeout":     15,
+		"force_mfa":         true,
+		"hidden_app":        true,
+		"rate_limit":        true,
+		"hsts_age":          300,
+		"sso":               false,
+		"idle_conn_ceil":    0,
+	} {
+		if data[key] != value {
+			t.Errorf("%s = %v, want %v", key, data[key], value)
+		}
+	}
+
+	if _, err := flattenSettingsBlock(advancedSettings, &client.AdvancedSettings_Complete{LoginTimeout: "soon"}); err == nil {
+		t.Error("a login_timeout that is not a number was accepted")
+	}
+}
+
+func TestUpgradeEaaApplicationStateV0(t *testing.T) {
+	state := map[string]interface{}{
+		"name": "app",
+		"advanced_settings": []interface{}{
+			map[string]interface{}{
+				"g2o_enabled":         "true",
+				"ip_access_allow":     "",
+				"x_wapp_read_timeout": "300",
+				"internal_host_port":  "not a port",
+				"internal_hostname":   "origin.internal",
+				"g2o_key":             "key",
+			},
+		},
+	}
+	upgraded, err := upgradeEaaApplicationStateV0(context.Background(), state, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data := upgraded["advanced_settings"].([]interface{})[0].(map[string]interface{})
+	want := map[string]interface{}{
+		"g2o_enabled":         true,
+		"ip_access_allow":     false,
+		"x_wapp_read_timeout": 300,
+		"internal_hostname":   "origin.internal",
+		"g2o_key":             "key",
+	}
+	for key, value := range want {
+		if data[key] != value {
+			t.Errorf("%s = %#v, want %#v", key, data[key], value)
+		}
+	}
+	if _, ok := data["internal_host_port"]; ok {
+		t.Error("internal_host_port was not dropped")
+	}
+}
+
+func TestEaaApplicationV0Type(t *testing.T) {
+	v0 := resourceEaaApplicationV0Type()
+	for _, name := range []string{"name", "agents", "service", "app_authentication", "advanced_settings"} {
+		if !v0.HasAttribute(name) {
+			t.Errorf("V0 type has no %s", name)
+		}
+	}
+	// attributes added after version 0 must not be part of it
+	for _, name := range []string{"saml", "oidc", "wsfed", "deploy", "tls_suite_name"} {
+		if v0.HasAttribute(name) {
+			t.Errorf("V0 type has %s", name)
+		}
+	}
+	settings := v0.AttributeType("advanced_settings").ElementType()
+	if got := settings.AttributeType("g2o_enabled"); got != cty.String {
+		t.Errorf("g2o_enabled is %s in V0, want string", got.FriendlyName())
+	}
+	if settings.HasAttribute("websocket_enabled") {
+		t.Error("V0 advanced_settings has websocket_enabled")
+	}
+}
@@ -0,0 +1,35 @@
+package eaaprovider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var corsMethods = []string{settingAny, "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// corsSettings are the settings of the cors block, the lists accept "*" for any value
+var corsSettings = []advancedSetting{
+	{Name: "allow_cors", Attr: "enabled", Kind: settingBool, Description: "Whether EAA answers CORS requests for the application"},
+	{
+		Name:        "cors_origin_list",
+		Attr:        "allowed_origins",
+		Kind:        settingList,
+		Validate:    validation.ToDiagFunc(validation.Any(validation.StringInSlice([]string{settingAny}, false), validation.IsURLWithHTTPorHTTPS)),
+		Description: "Origins allowed to call the application, e.g. https://app.example.com, or \"*\" for any origin",
+	},
+	{
+		Name:        "cors_method_list",
+		Attr:        "allowed_methods",
+		Kind:        settingList,
+		Validate:    validation.ToDiagFunc(validation.StringInSlice(corsMethods, false)),
+		Description: "HTTP methods allowed in CORS requests, or \"*\" for any method",
+	},
+	{
+		Name:        "cors_header_list",
+		Attr:        "allowed_headers",
+		Kind:        settingList,
+		Validate:    validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+		Description: "Request headers allowed in CORS requests, or \"*\" for any header",
+	},
+	{Name: "cors_support_credential", Attr: "support_credentials", Kind: settingSwitch, Description: "Whether CORS requests may carry cookies and credentials"},
+	{Name: "cors_max_age", Attr: "max_age", Kind: settingInt, Validate: validatePositive, Description: "Time browsers cache the preflight response, in seconds"},
+}
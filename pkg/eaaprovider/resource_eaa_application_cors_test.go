@@ -0,0 +1,149 @@
+package eaaprovider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationCORS(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	config := testApplicationConfig("tf-cors-app", "terraform-test-connector")
+	config["cors"] = []interface{}{
+		map[string]interface{}{
+			"enabled":             true,
+			"allowed_origins":     []interface{}{"https://spa.example.com", "https://admin.example.com"},
+			"allowed_methods":     []interface{}{"GET", "POST"},
+			"allowed_headers":     []interface{}{"*"},
+			"support_credentials": true,
+			"max_age":             600,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	sent := map[string]string{
+		"allow_cors":              client.STR_TRUE,
+		"cors_origin_list":        "https://spa.example.com https://admin.example.com",
+		"cors_method_list":        "GET POST",
+		"cors_header_list":        client.STR_UNBOUNDED,
+		"cors_support_credential": client.STR_ON,
+		"cors_max_age":            "600",
+	}
+	for key, want := range sent {
+		if got, _ := app.AdvancedSettings.Get(key); got != want {
+			t.Errorf("sent %s = %q, want %q", key, got, want)
+		}
+	}
+
+	want := map[string]interface{}{
+		"enabled":             true,
+		"allowed_origins":     []interface{}{"https://spa.example.com", "https://admin.example.com"},
+		"allowed_methods":     []interface{}{"GET", "POST"},
+		"allowed_headers":     []interface{}{"*"},
+		"support_credentials": true,
+		"max_age":             600,
+	}
+	for key, value := range want {
+		if got := d.Get("cors.0." + key); !reflect.DeepEqual(got, value) {
+			t.Errorf("cors.0.%s = %#v, want %#v", key, got, value)
+		}
+	}
+}
+
+func TestCORSListFromAPI(t *testing.T) {
+	setting := corsSettings[1]
+	for value, want := range map[string][]interface{}{
+		"":                             {},
+		client.STR_UNBOUNDED:           {"*"},
+		"https://a.example.com":        {"https://a.example.com"},
+		"https://a.com, https://b.com": {"https://a.com", "https://b.com"},
+	} {
+		got, err := setting.fromAPI(value, value != "")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("fromAPI(%q) = %#v, want %#v", value, got, want)
+		}
+	}
+}
+
+func TestEaaApplicationCORSPlan(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"explicit lists": {
+			"enabled":             true,
+			"allowed_origins":     []interface{}{"https://spa.example.com", "https://admin.example.com"},
+			"allowed_methods":     []interface{}{"GET", "POST"},
+			"support_credentials": true,
+		},
+		"any origin": {
+			"enabled":         true,
+			"allowed_origins": []interface{}{"*"},
+			"allowed_headers": []interface{}{"*"},
+			"max_age":         600,
+		},
+		"disabled": {
+			"enabled": false,
+		},
+	}
+	for name, cors := range tests {
+		t.Run(name, func(t *testing.T) {
+			tf := newTestTerraform(t, newTestAPI(t))
+			config := testApplicationConfig("tf-cors-app", "terraform-test-connector")
+			config["cors"] = []interface{}{cors}
+			tf.applyAndReplan(config)
+		})
+	}
+}
+
+func TestEaaApplicationCORSUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  cors {
+    enabled         = true
+    allowed_origins = ["https://spa.example.com"]
+    allowed_headers = ["*"]
+  }
+`),
+				Check: resource.TestCheckResourceAttr("eaa_application.app", "cors.0.allowed_headers.0", "*"),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  cors {
+    allowed_origins = ["*", "https://spa.example.com"]
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("allowed_origins cannot combine"),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  cors {
+    allowed_origins     = ["*"]
+    support_credentials = true
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("support_credentials requires explicit allowed_origins"),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package eaaprovider
+
+import (
+	"context"
+	"time"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	DEPLOY_AUTO = "auto"
+	DEPLOY_SKIP = "skip"
+
+	DEFAULT_DEPLOY_TIMEOUT = 20 * time.Minute
+)
+
+// localDeployAttrs only shape the next deployment, a change of them alone is kept in the state without an update
+var localDeployAttrs = []string{"deploy_note", "wait_for_deployment"}
+
+// deploymentPollInterval is how often wait_for_deployment reads the application
+var deploymentPollInterval = 15 * time.Second
+
+func deploySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deploy": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          DEPLOY_AUTO,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{DEPLOY_AUTO, DEPLOY_SKIP}, false)),
+			Description:      "auto deploys the application after every change, skip leaves the changes staged",
+		},
+		"deploy_note": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     client.DEFAULT_DEPLOY_NOTE,
+			Description: "Note of the deployments, shown in the deployment history",
+		},
+		"wait_for_deployment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether apply waits until the application is deployed",
+		},
+	}
+}
+
+// deployApplication deploys app unless deploy is skip and, with wait_for_deployment, waits until it is deployed.
+// The wait ends with ctx, which carries the create or update timeout of the resource.
+func deployApplication(ctx context.Context, d *schema.ResourceData, ec *client.EaaClient, app *client.Application) error {
+	if d.Get("deploy").(string) == DEPLOY_SKIP {
+		ec.Logger.Info("deploy is skip, the changes stay staged", "uuid", app.UUIDURL)
+		return nil
+	}
+	if err := app.DeployApplicationWithNote(ctx, ec, d.Get("deploy_note").(string)); err != nil {
+		return err
+	}
+	if !d.Get("wait_for_deployment").(bool) {
+		return nil
+	}
+	_, err := client.WaitForDeployment(ctx, ec, app.UUIDURL, deploymentPollInterval)
+	return err
+}
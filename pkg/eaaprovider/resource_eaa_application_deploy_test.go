@@ -0,0 +1,131 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationDeploy(t *testing.T) {
+	ctx := context.Background()
+	interval := deploymentPollInterval
+	deploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { deploymentPollInterval = interval })
+
+	t.Run("skip", func(t *testing.T) {
+		srv := newTestAPI(t)
+		config := testApplicationConfig("tf-app")
+		config["deploy"] = DEPLOY_SKIP
+		d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+		if diags := resourceEaaApplicationCreate(ctx, d, testMeta(srv)); diags.HasError() {
+			t.Fatalf("create failed: %v", diags)
+		}
+		if got := srv.Deployments(d.Id()); got != 0 {
+			t.Errorf("deployments = %d, want 0", got)
+		}
+		if d.Get("app_deployed").(bool) {
+			t.Error("app_deployed = true, want false")
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		srv := newTestAPI(t)
+		srv.SlowDeployments(3)
+		config := testApplicationConfig("tf-app")
+		config["deploy_note"] = "release 42"
+		config["wait_for_deployment"] = true
+		d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+		if diags := resourceEaaApplicationCreate(ctx, d, testMeta(srv)); diags.HasError() {
+			t.Fatalf("create failed: %v", diags)
+		}
+		if got := srv.DeployNote(d.Id()); got != "release 42" {
+			t.Errorf("deploy_note = %q, want release 42", got)
+		}
+		if d.Get("app_operational") != client.APP_OPERATIONAL || !d.Get("app_deployed").(bool) {
+			t.Errorf("app_operational = %v, app_deployed = %v after waiting", d.Get("app_operational"), d.Get("app_deployed"))
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		srv := newTestAPI(t)
+		srv.SlowDeployments(2)
+		srv.FailDeployments()
+		config := testApplicationConfig("tf-app")
+		config["wait_for_deployment"] = true
+		d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+		diags := resourceEaaApplicationCreate(ctx, d, testMeta(srv))
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "deployment failed, app_deployed false, app_status 3") {
+			t.Errorf("diags = %v, want the failed deployment", diags)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := newTestAPI(t)
+		srv.SlowDeployments(1000)
+		config := testApplicationConfig("tf-app")
+		config["wait_for_deployment"] = true
+		d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+		// the SDK passes a context with the create timeout of the resource
+		createCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+		diags := resourceEaaApplicationCreate(createCtx, d, testMeta(srv))
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "not deployed and operational in time, app_deployed false, app_status 2") {
+			t.Errorf("diags = %v, want a deploy error reporting the pending status", diags)
+		}
+	})
+}
+
+func TestWaitForDeploymentTimeout(t *testing.T) {
+	srv := newTestAPI(t)
+	srv.SlowDeployments(1000)
+	meta := testMeta(srv)
+	ec := meta.EaaClient
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig("tf-app"))
+	if diags := resourceEaaApplicationCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.WaitForDeployment(waitCtx, ec, d.Id(), time.Millisecond)
+	if !errors.Is(err, client.ErrDeploy) || !strings.Contains(err.Error(), "app_deployed false, app_status 2") {
+		t.Errorf("err = %v, want a deploy error reporting the pending status", err)
+	}
+}
+
+func TestEaaApplicationLocalDeployAttrs(t *testing.T) {
+	srv := newTestAPI(t)
+	tf := newTestTerraform(t, srv)
+	config := testApplicationConfig("tf-app")
+	tf.applyAndReplan(config)
+	id := tf.state.ID
+
+	// neither a PUT nor a deployment may be sent, the PUT would fail
+	srv.FailNext(http.MethodPut, "apps/*", http.StatusInternalServerError)
+	config["deploy_note"] = "release 43"
+	config["wait_for_deployment"] = true
+	tf.applyAndReplan(config)
+	if got := srv.Deployments(id); got != 1 {
+		t.Errorf("deployments = %d, want 1", got)
+	}
+	if got := tf.state.Attributes["deploy_note"]; got != "release 43" {
+		t.Errorf("deploy_note = %q, want release 43", got)
+	}
+
+	// the next change is deployed with the new note
+	config["host"] = "tf-app-2"
+	srv.SlowDeployments(0)
+	if err := tf.apply(config); err == nil {
+		t.Fatal("expected the pending PUT failure")
+	}
+	tf.applyAndReplan(config)
+	if got := srv.DeployNote(id); got != "release 43" {
+		t.Errorf("deploy note = %q, want release 43", got)
+	}
+}
@@ -0,0 +1,69 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IMPORT_BY_NAME prefixes the import IDs holding an application name or a name pattern instead of a uuid_url
+const IMPORT_BY_NAME = "name:"
+
+var (
+	ErrImportNoMatch   = errors.New("no application matches the import ID")
+	ErrImportAmbiguous = errors.New("several applications match the import ID")
+)
+
+// importEaaApplication imports an application by uuid_url, or by name with an import ID of the form name:<app name>.
+// The deploy attributes are not read from the API, they get their defaults so that the import is not followed by an update.
+func importEaaApplication(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if pattern, ok := strings.CutPrefix(d.Id(), IMPORT_BY_NAME); ok {
+		ec, err := Client(m)
+		if err != nil {
+			return nil, err
+		}
+		id, err := findApplicationByName(ctx, ec, pattern)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+	}
+
+	for name, attr := range deploySchema() {
+		if err := d.Set(name, attr.Default); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// findApplicationByName returns the uuid_url of the only application whose name matches pattern.
+// pattern is matched by client.MatchApplicationNames: an equal name wins, else * and ? select several names.
+func findApplicationByName(ctx context.Context, ec *client.EaaClient, pattern string) (string, error) {
+	apps, err := client.GetApplications(ctx, ec)
+	if err != nil {
+		return "", err
+	}
+
+	matches := client.MatchApplicationNames(apps, pattern)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %s%s", ErrImportNoMatch, IMPORT_BY_NAME, pattern)
+	case 1:
+		return matches[0].UUIDURL, nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, app := range matches {
+		candidates[i] = fmt.Sprintf("%s (%s)", app.Name, app.UUIDURL)
+	}
+	sort.Strings(candidates)
+	return "", fmt.Errorf("%w: %s%s matches %s, import one of them by uuid_url",
+		ErrImportAmbiguous, IMPORT_BY_NAME, pattern, strings.Join(candidates, ", "))
+}
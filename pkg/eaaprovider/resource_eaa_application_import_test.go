@@ -0,0 +1,69 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationImport(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	ids := map[string]string{}
+	for _, name := range []string{"tf-app", "tf-app-2", "wiki", "app[1]", "app1", "apps/jira"} {
+		d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig(name))
+		if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+			t.Fatalf("create %s failed: %v", name, diags)
+		}
+		ids[name] = d.Id()
+	}
+
+	tests := map[string]struct {
+		id      string
+		wantID  string
+		wantErr error
+		want    []string
+	}{
+		"uuid":         {id: ids["wiki"], wantID: ids["wiki"]},
+		"name":         {id: "name:tf-app", wantID: ids["tf-app"]},
+		"pattern":      {id: "name:w*", wantID: ids["wiki"]},
+		"case":         {id: "name:WIKI", wantID: ids["wiki"]},
+		"literal name": {id: "name:app[1]", wantID: ids["app[1]"]},
+		"slash":        {id: "name:*/jira", wantID: ids["apps/jira"]},
+		"no match":     {id: "name:jira", wantErr: ErrImportNoMatch},
+		"ambiguous":    {id: "name:tf-app*", wantErr: ErrImportAmbiguous, want: []string{"tf-app (" + ids["tf-app"] + ")", "tf-app-2 (" + ids["tf-app-2"] + ")"}},
+		"bracket":      {id: "name:[tf", wantErr: ErrImportNoMatch},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := resourceEaaApplication().TestResourceData()
+			d.SetId(tt.id)
+			imported, err := importEaaApplication(ctx, d, meta)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				for _, candidate := range tt.want {
+					if !strings.Contains(err.Error(), candidate) {
+						t.Errorf("err = %v, want it to list %s", err, candidate)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("import failed: %s", err)
+			}
+			if got := imported[0].Id(); got != tt.wantID {
+				t.Errorf("id = %s, want %s", got, tt.wantID)
+			}
+			if got := imported[0].Get("deploy"); got != DEPLOY_AUTO {
+				t.Errorf("deploy = %v, want %s", got, DEPLOY_AUTO)
+			}
+		})
+	}
+}
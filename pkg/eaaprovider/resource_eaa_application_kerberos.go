@@ -0,0 +1,102 @@
+package eaaprovider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	APP_AUTH_NONE     = "none"
+	APP_AUTH_KERBEROS = "kerberos"
+)
+
+// appAuthMethods are the ways the connector authenticates users to the origin
+var appAuthMethods = []string{APP_AUTH_NONE, "basic", "NTLMv1", "NTLMv2", APP_AUTH_KERBEROS}
+
+// kerberosSettings are the settings of the kerberos block that are stored in the state as they are
+var kerberosSettings = []advancedSetting{
+	{Name: "app_auth", Kind: settingString, Validate: validation.ToDiagFunc(validation.StringInSlice(appAuthMethods, false)), Description: "How the connector authenticates users to the origin: none, basic, NTLMv1, NTLMv2 or kerberos"},
+	{Name: "service_principle_name", Attr: "service_principal_name", Kind: settingString, Description: "Service principal name of the origin, e.g. HTTP/sharepoint.example.com"},
+	{Name: "kerberos_negotiate_once", Attr: "negotiate_once", Kind: settingBool, Description: "Whether the Kerberos negotiation happens once per session instead of once per request"},
+	{Name: "forward_ticket_granting_ticket", Kind: settingBool, Description: "Whether the ticket granting ticket of the user is forwarded to the origin"},
+}
+
+// hashFile is the StateFunc of keytab_file, the state keeps the SHA-256 of the content so changes to the file are planned
+func hashFile(v interface{}) string {
+	path, _ := v.(string)
+	if path == "" {
+		return ""
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func validateReadableFile(i interface{}, k string) ([]string, []error) {
+	path, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := os.ReadFile(path); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+func kerberosSchema() *schema.Schema {
+	block := settingsBlockSchema(kerberosSettings)
+	attrs := block.Elem.(*schema.Resource).Schema
+	attrs["keytab"] = secretSchema("Base64 encoded keytab of the service account, e.g. filebase64(\"svc.keytab\")", validation.ToDiagFunc(validation.StringIsBase64))
+	attrs["keytab"].ConflictsWith = []string{"kerberos.0.keytab_file"}
+	attrs["keytab_file"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        true,
+		StateFunc:        hashFile,
+		ValidateDiagFunc: validation.ToDiagFunc(validateReadableFile),
+		ConflictsWith:    []string{"kerberos.0.keytab"},
+		Description:      "Path of the keytab file of the service account",
+	}
+	return block
+}
+
+// expandKerberos copies the kerberos block into settings, the keytab only when it changed
+func expandKerberos(d *schema.ResourceData, settings *client.AdvancedSettings_Complete) error {
+	if err := expandSettingsBlock(d, "kerberos", kerberosSettings, settings); err != nil {
+		return err
+	}
+	if keytab, ok := changedSecret(d, "kerberos.0.keytab"); ok && keytab != "" {
+		settings.Keytab = keytab
+	}
+	if path, ok := changedSecret(d, "kerberos.0.keytab_file"); ok && path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%w: keytab_file: %s", ErrInvalidData, err)
+		}
+		settings.Keytab = base64.StdEncoding.EncodeToString(content)
+	}
+	return nil
+}
+
+// flattenKerberos returns the kerberos block of an application, the keytab hashes are kept from the state
+func flattenKerberos(d *schema.ResourceData, settings *client.AdvancedSettings_Complete) ([]interface{}, error) {
+	block, err := flattenSettingsBlock(kerberosSettings, settings)
+	if err != nil {
+		return nil, err
+	}
+	data := block[0].(map[string]interface{})
+	data["keytab"] = secretState(d, "kerberos.0.keytab", hashSecret)
+	data["keytab_file"] = secretState(d, "kerberos.0.keytab_file", hashFile)
+	return block, nil
+}
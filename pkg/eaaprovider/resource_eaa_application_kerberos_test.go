@@ -0,0 +1,191 @@
+package eaaprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEaaApplicationKerberos(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	keytab := []byte("\x05\x02keytab of svc-sharepoint")
+	keytabFile := filepath.Join(t.TempDir(), "svc.keytab")
+	if err := os.WriteFile(keytabFile, keytab, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := testApplicationConfig("tf-kerberos-app", "terraform-test-connector")
+	config["kerberos"] = []interface{}{
+		map[string]interface{}{
+			"app_auth":               "kerberos",
+			"service_principal_name": "HTTP/sharepoint.example.com",
+			"negotiate_once":         true,
+			"keytab_file":            keytabFile,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	sent := map[string]string{
+		"app_auth":                "kerberos",
+		"service_principle_name":  "HTTP/sharepoint.example.com",
+		"kerberos_negotiate_once": client.STR_TRUE,
+		"keytab":                  base64.StdEncoding.EncodeToString(keytab),
+	}
+	for key, want := range sent {
+		if got, _ := app.AdvancedSettings.Get(key); got != want {
+			t.Errorf("sent %s = %q, want %q", key, got, want)
+		}
+	}
+
+	state := d.State().Attributes
+	if got := state["kerberos.0.keytab_file"]; got != hashFile(keytabFile) || got == "" {
+		t.Errorf("keytab_file is stored as %q, want the hash of the file", got)
+	}
+	if got := state["kerberos.0.service_principal_name"]; got != "HTTP/sharepoint.example.com" {
+		t.Errorf("service_principal_name = %q", got)
+	}
+}
+
+func TestKerberosKeytabValidation(t *testing.T) {
+	r := resourceEaaApplication()
+	for name, block := range map[string]map[string]interface{}{
+		"missing file":   {"keytab_file": filepath.Join(t.TempDir(), "missing.keytab")},
+		"not base64":     {"keytab": "not base64!"},
+		"file and value": {"keytab": "a2V5dGFi", "keytab_file": "svc.keytab"},
+	} {
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"name": "app", "kerberos": []interface{}{block}}))
+		if !diags.HasError() {
+			t.Errorf("%s: was accepted", name)
+		}
+	}
+}
+
+func TestEaaApplicationKerberosKeytabPlan(t *testing.T) {
+	keytab := []byte("\x05\x02keytab of svc-sharepoint")
+	encoded := base64.StdEncoding.EncodeToString(keytab)
+	keytabFile := filepath.Join(t.TempDir(), "svc.keytab")
+	if err := os.WriteFile(keytabFile, keytab, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		attr  string
+		value string
+		want  string
+	}{
+		"keytab":      {attr: "keytab", value: encoded, want: hashSecret(encoded)},
+		"keytab_file": {attr: "keytab_file", value: keytabFile, want: hashFile(keytabFile)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := newTestAPI(t)
+			tf := newTestTerraform(t, srv)
+
+			config := testApplicationConfig("tf-kerberos-app", "terraform-test-connector")
+			config["kerberos"] = []interface{}{
+				map[string]interface{}{
+					"app_auth":               "kerberos",
+					"service_principal_name": "HTTP/sharepoint.example.com",
+					tt.attr:                  tt.value,
+				},
+			}
+			tf.applyAndReplan(config)
+			for key, value := range tf.state.Attributes {
+				if value == encoded || value == keytabFile {
+					t.Errorf("%s holds the keytab in the state", key)
+				}
+			}
+			if got := tf.state.Attributes["kerberos.0."+tt.attr]; got != tt.want {
+				t.Errorf("%s is stored as %q, want %q", tt.attr, got, tt.want)
+			}
+			app, _ := srv.App(tf.state.ID)
+			if got, _ := app.AdvancedSettings.Get("keytab"); got != encoded {
+				t.Errorf("sent keytab = %q, want %q", got, encoded)
+			}
+		})
+	}
+}
+
+func TestEaaApplicationKerberosKeytabFileChange(t *testing.T) {
+	keytabFile := filepath.Join(t.TempDir(), "svc.keytab")
+	if err := os.WriteFile(keytabFile, []byte("keytab v1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestAPI(t)
+	tf := newTestTerraform(t, srv)
+
+	config := testApplicationConfig("tf-kerberos-app", "terraform-test-connector")
+	config["kerberos"] = []interface{}{
+		map[string]interface{}{
+			"app_auth":               "kerberos",
+			"service_principal_name": "HTTP/sharepoint.example.com",
+			"keytab_file":            keytabFile,
+		},
+	}
+	tf.applyAndReplan(config)
+
+	// the path is unchanged, the new content of the file must still be planned and sent
+	if err := os.WriteFile(keytabFile, []byte("keytab v2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := tf.plan(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := diff.Attributes["kerberos.0.keytab_file"]; attr == nil || attr.New != hashFile(keytabFile) {
+		t.Fatalf("keytab_file diff = %+v, want the hash of the new content", attr)
+	}
+	tf.applyAndReplan(config)
+	app, _ := srv.App(tf.state.ID)
+	if got, _ := app.AdvancedSettings.Get("keytab"); got != base64.StdEncoding.EncodeToString([]byte("keytab v2")) {
+		t.Errorf("sent keytab = %q, want the new content", got)
+	}
+}
+
+func TestEaaApplicationKerberosUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	keytab := base64.StdEncoding.EncodeToString([]byte("keytab of svc-sharepoint"))
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				// the step fails when the plan after apply is not empty, e.g. if the state held the keytab
+				Config: testUnitAppConfig("http", "enterprise", fmt.Sprintf(`
+  kerberos {
+    app_auth               = "kerberos"
+    service_principal_name = "HTTP/sharepoint.example.com"
+    keytab                 = %q
+  }
+`, keytab)),
+				Check: resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.keytab", hashSecret(keytab)),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  kerberos {
+    app_auth = "kerberos"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("service_principal_name is required"),
+			},
+		},
+	})
+}
@@ -0,0 +1,27 @@
+package eaaprovider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	lbMetrics          = []string{"round-robin", "ip-hash"}
+	healthCheckTypes   = []string{"Default", "HTTP", "HTTPS", "TLS", "SSLv3", "TCP", "None"}
+	healthCheckVersion = []string{"1.0", "1.1"}
+)
+
+// loadBalancingSettings are the settings of the load_balancing block, they apply across the servers of the application
+var loadBalancingSettings = []advancedSetting{
+	{Name: "load_balancing_metric", Attr: "metric", Kind: settingString, Validate: validation.ToDiagFunc(validation.StringInSlice(lbMetrics, false)), Description: "How requests are spread over the servers: round-robin or ip-hash"},
+	{Name: "session_sticky", Kind: settingBool, Description: "Whether a user stays on the same server"},
+	{Name: "session_sticky_cookie_maxage", Kind: settingInt, Validate: validatePositive, Description: "max-age of the sticky session cookie in seconds, 0 keeps it for the browser session"},
+	{Name: "refresh_sticky_cookie", Kind: settingSwitch, Description: "Whether the sticky session cookie is refreshed on every response"},
+	{Name: "health_check_type", Kind: settingString, Validate: validation.ToDiagFunc(validation.StringInSlice(healthCheckTypes, false)), Description: "Health check of the servers: Default, HTTP, HTTPS, TLS, SSLv3, TCP or None"},
+	{Name: "health_check_http_url", Kind: settingString, Description: "Path requested by the HTTP and HTTPS health checks"},
+	{Name: "health_check_http_version", Kind: settingString, Validate: validation.ToDiagFunc(validation.StringInSlice(healthCheckVersion, false)), Description: "HTTP version of the HTTP and HTTPS health checks: 1.0 or 1.1"},
+	{Name: "health_check_http_host_header", Kind: settingString, Description: "Host header sent by the HTTP and HTTPS health checks"},
+	{Name: "health_check_rise", Kind: settingInt, Validate: validation.ToDiagFunc(validation.IntAtLeast(1)), Description: "Successful checks after which a server is considered up"},
+	{Name: "health_check_fall", Kind: settingInt, Validate: validation.ToDiagFunc(validation.IntAtLeast(1)), Description: "Failed checks after which a server is considered down"},
+	{Name: "health_check_timeout", Kind: settingInt, Validate: validation.ToDiagFunc(validation.IntAtLeast(1)), Description: "Timeout of a health check in milliseconds"},
+	{Name: "health_check_interval", Kind: settingInt, Validate: validation.ToDiagFunc(validation.IntAtLeast(1)), Description: "Interval between health checks in milliseconds"},
+}
@@ -0,0 +1,122 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationLoadBalancing(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	config := testApplicationConfig("tf-lb-app", "terraform-test-connector")
+	config["load_balancing"] = []interface{}{
+		map[string]interface{}{
+			"metric":                "ip-hash",
+			"session_sticky":        true,
+			"refresh_sticky_cookie": true,
+			"health_check_type":     "HTTPS",
+			"health_check_http_url": "/healthz",
+			"health_check_rise":     2,
+			"health_check_interval": 30000,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	sent := map[string]string{
+		"load_balancing_metric": "ip-hash",
+		"session_sticky":        client.STR_TRUE,
+		"refresh_sticky_cookie": client.STR_ON,
+		"health_check_type":     "HTTPS",
+		"health_check_http_url": "/healthz",
+		"health_check_rise":     "2",
+		"health_check_interval": "30000",
+	}
+	for key, want := range sent {
+		if got, _ := app.AdvancedSettings.Get(key); got != want {
+			t.Errorf("sent %s = %q, want %q", key, got, want)
+		}
+	}
+	if _, ok := app.AdvancedSettings.Get("health_check_fall"); ok {
+		t.Error("health_check_fall is sent although it is not configured")
+	}
+
+	checks := map[string]string{
+		"load_balancing.0.metric":                "ip-hash",
+		"load_balancing.0.session_sticky":        "true",
+		"load_balancing.0.refresh_sticky_cookie": "true",
+		"load_balancing.0.health_check_type":     "HTTPS",
+		"load_balancing.0.health_check_rise":     "2",
+		"load_balancing.0.health_check_fall":     "0",
+	}
+	for key, want := range checks {
+		if got := fmt.Sprint(d.Get(key)); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEaaApplicationLoadBalancingPlan(t *testing.T) {
+	srv := newTestAPI(t)
+	tf := newTestTerraform(t, srv)
+
+	config := testApplicationConfig("tf-lb-app", "terraform-test-connector")
+	config["load_balancing"] = []interface{}{
+		map[string]interface{}{
+			"metric":            "ip-hash",
+			"health_check_type": "TCP",
+		},
+	}
+	tf.applyAndReplan(config)
+
+	config["load_balancing"] = []interface{}{
+		map[string]interface{}{
+			"metric":            "ip-hash",
+			"health_check_type": "HTTPS",
+		},
+	}
+	if _, err := tf.plan(config); !errors.Is(err, ErrInvalidAppConfig) {
+		t.Errorf("err = %v, want the missing health_check_http_url reported", err)
+	}
+}
+
+func TestEaaApplicationLoadBalancingUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  load_balancing {
+    metric            = "ip-hash"
+    health_check_type = "TCP"
+  }
+`),
+				Check: resource.TestCheckResourceAttr("eaa_application.app", "load_balancing.0.metric", "ip-hash"),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  load_balancing {
+    health_check_type = "HTTP"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("health_check_http_url is required"),
+			},
+		},
+	})
+}
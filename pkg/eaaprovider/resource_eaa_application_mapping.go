@@ -0,0 +1,552 @@
+package eaaprovider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// This file maps the eaa_application schema to the plain request and response types of pkg/client.
+// expand* functions read the configuration, flatten* functions build the values stored in the state.
+
+// expandCreateAppRequest builds the create request from the name, description, app_type, app_profile and client_app_mode attributes
+func expandCreateAppRequest(d *schema.ResourceData, ec *client.EaaClient) (*client.CreateAppRequest, error) {
+	logger := ec.Logger
+	car := &client.CreateAppRequest{}
+	if name, ok := d.GetOk("name"); ok {
+		nameStr, ok := name.(string)
+		if ok && nameStr != "" {
+			car.Name = name.(string)
+		}
+	} else {
+		logger.Error("create Application failed. name is invalid")
+		return nil, client.ErrInvalidValue
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		descriptionStr, ok := description.(string)
+		if ok && descriptionStr != "" {
+			car.Description = &descriptionStr
+		}
+	}
+
+	if appType, ok := d.GetOk("app_type"); ok {
+		strAppType, ok := appType.(string)
+		if !ok {
+			logger.Error("create Application failed. app_type is invalid")
+			return nil, client.ErrInvalidType
+		}
+		atype := client.ClientAppType(strAppType)
+		value, err := atype.ToInt()
+		if err != nil {
+			logger.Error("create Application failed. app_type is invalid")
+			return nil, client.ErrInvalidValue
+		}
+		car.AppType = value
+	} else {
+		logger.Info("appType is not present, defaulting to enterprise")
+		car.AppType = int(client.APP_TYPE_ENTERPRISE_HOSTED)
+	}
+
+	if appProfile, ok := d.GetOk("app_profile"); ok {
+		strappProfile, ok := appProfile.(string)
+		if !ok {
+			logger.Error("create Application failed. app_profile is invalid")
+			return nil, client.ErrInvalidType
+		}
+		aProfile := client.AppProfile(strappProfile)
+		value, err := aProfile.ToInt()
+		if err != nil {
+			logger.Error("create Application failed. app_profile is invalid")
+			return nil, client.ErrInvalidValue
+		}
+		car.AppProfile = value
+	} else {
+		logger.Info("appProfile is not present, defaulting to http")
+		car.AppProfile = int(client.APP_PROFILE_HTTP)
+	}
+
+	if clientAppMode, ok := d.GetOk("client_app_mode"); ok {
+		appMode, ok := clientAppMode.(string)
+		if !ok {
+			logger.Error("create Application failed. clientAppMode is invalid")
+			return nil, client.ErrInvalidType
+		}
+		aMode := client.ClientAppMode(appMode)
+		value, err := aMode.ToInt()
+		if err != nil {
+			logger.Error("create Application failed. clientAppMode is invalid")
+			return nil, client.ErrInvalidValue
+		}
+		car.ClientAppMode = value
+	} else {
+		logger.Info("appMode is not present, defaulting to tcp")
+		car.ClientAppMode = int(client.CLIENT_APP_MODE_TCP)
+	}
+	return car, nil
+}
+
+// expandUpdateAppRequest fills appUpdateReq from the configuration.
+// It resolves app_category, popregion and the custom domain certificate and requests the g2o and edge authentication keys when they are enabled.
+func expandUpdateAppRequest(ctx context.Context, d *schema.ResourceData, ec *client.EaaClient, appUpdateReq *client.ApplicationUpdateRequest) error {
+	ec.Logger.Info("updating application")
+	appUpdateReq.TunnelInternalHosts = []client.TunnelInternalHost{}
+	if tunnelInternalHosts, ok := d.GetOk("tunnel_internal_hosts"); ok {
+		if tunnelInternalHostsList, ok := tunnelInternalHosts.([]interface{}); ok {
+			for _, th := range tunnelInternalHostsList {
+				if thData, ok := th.(map[string]interface{}); ok {
+					tunnelInternalHost := client.TunnelInternalHost{}
+					if h, ok := thData["host"].(string); ok {
+						tunnelInternalHost.Host = h
+					}
+					if pr, ok := thData["port_range"].(string); ok {
+						tunnelInternalHost.PortRange = pr
+					}
+					if pt, ok := thData["proto_type"].(int); ok {
+						tunnelInternalHost.ProtoType = pt
+					}
+					appUpdateReq.TunnelInternalHosts = append(appUpdateReq.TunnelInternalHosts, tunnelInternalHost)
+				}
+			}
+		}
+	}
+
+	if ac, ok := d.GetOk("app_category"); ok {
+		if acValue, ok := ac.(string); ok {
+
+			if acValue != "" {
+				uuid, err := client.GetAppCategoryUuid(ctx, ec, acValue)
+				if err == nil {
+					category := client.AppCategory{}
+					category.Name = acValue
+					category.UUID_URL = uuid
+					appUpdateReq.AppCategory = category
+				}
+			}
+		}
+	}
+
+	if err := expandSettingsBlock(d, "advanced_settings", advancedSettings, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if err := expandSettingsBlock(d, "load_balancing", loadBalancingSettings, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if err := expandSettingsBlock(d, "cors", corsSettings, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if err := expandRDPSettings(d, appUpdateReq); err != nil {
+		return err
+	}
+	if err := expandSSHSettings(d, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if err := expandKerberos(d, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if err := expandSAML(d, appUpdateReq); err != nil {
+		return err
+	}
+	if err := expandOIDC(d, appUpdateReq); err != nil {
+		return err
+	}
+	if err := expandWSFed(d, appUpdateReq); err != nil {
+		return err
+	}
+	if enabled, ok := d.GetOk("advanced_settings.0.g2o_enabled"); ok && enabled.(bool) {
+		g2oResp, err := appUpdateReq.Application.UpdateG2O(ctx, ec)
+		if err != nil {
+			ec.Logger.Error("g2o request failed. err: ", err)
+			return err
+		}
+		appUpdateReq.AdvancedSettings.G2OEnabled = client.STR_TRUE
+		appUpdateReq.AdvancedSettings.G2OKey = &g2oResp.G2OKey
+		appUpdateReq.AdvancedSettings.G2ONonce = &g2oResp.G2ONonce
+	}
+	if enabled, ok := d.GetOk("advanced_settings.0.edge_authentication_enabled"); ok && enabled.(bool) {
+		edgeAuthResp, err := appUpdateReq.Application.UpdateEdgeAuthentication(ctx, ec)
+		if err != nil {
+			ec.Logger.Error("edge auth cookie request failed. err: ", err)
+			return err
+		}
+		appUpdateReq.AdvancedSettings.EdgeAuthenticationEnabled = client.STR_TRUE
+		appUpdateReq.AdvancedSettings.EdgeCookieKey = edgeAuthResp.EdgeCookieKey
+		appUpdateReq.AdvancedSettings.SLAObjectURL = edgeAuthResp.SlaObjectUrl
+	}
+	appUpdateReq.Servers = []client.Server{}
+	if servers, ok := d.GetOk("servers"); ok {
+		if serversList, ok := servers.([]interface{}); ok {
+			for _, s := range serversList {
+				if sData, ok := s.(map[string]interface{}); ok {
+					server := client.Server{}
+					if oh, ok := sData["origin_host"].(string); ok {
+						server.OriginHost = oh
+					}
+					if ot, ok := sData["orig_tls"].(bool); ok {
+						server.OrigTLS = ot
+					}
+					if op, ok := sData["origin_port"].(int); ok {
+						server.OriginPort = op
+					}
+					if opr, ok := sData["origin_protocol"].(string); ok {
+						server.OriginProtocol = opr
+					}
+					appUpdateReq.Servers = append(appUpdateReq.Servers, server)
+				}
+			}
+		}
+	}
+
+	if bookmarkURL, ok := d.GetOk("bookmark_url"); ok {
+		if bm, ok := bookmarkURL.(string); ok {
+			appUpdateReq.BookmarkURL = bm
+		}
+	}
+
+	if suite, ok := d.GetOk("tls_suite_name"); ok {
+		if name, ok := suite.(string); ok {
+			// the plan of a new application could not check the suite, see validateTLSSuite
+			if d.HasChanges("tls_suite_name", "allow_weak_tls_cipher") {
+				if err := checkTLSSuite(&appUpdateReq.Application, name, d.Get("allow_weak_tls_cipher").(bool)); err != nil {
+					return err
+				}
+			}
+			appUpdateReq.TLSSuiteName = &name
+		}
+	}
+
+	if host, ok := d.GetOk("host"); ok {
+		if hv, ok := host.(string); ok {
+			appUpdateReq.Host = &hv
+		}
+	}
+
+	if authEnabled, ok := d.GetOk("auth_enabled"); ok {
+		if ae, ok := authEnabled.(string); ok {
+			appUpdateReq.AuthEnabled = ae
+		}
+	}
+
+	if popRegion, ok := d.GetOk("popregion"); ok {
+		if popregionstr, ok := popRegion.(string); ok {
+			appUpdateReq.POPRegion = popregionstr
+			if popRegion != "" {
+				popname, uuid, err := client.GetPopUuid(ctx, ec, popregionstr)
+				if err == nil {
+					appUpdateReq.POPName = popname
+					appUpdateReq.POP = uuid
+				}
+			}
+		}
+	}
+
+	if domain, ok := d.GetOk("domain"); ok {
+		if strDomain, ok := domain.(string); ok {
+			appDomain := client.Domain(strDomain)
+			value, err := appDomain.ToInt()
+			if err != nil {
+				ec.Logger.Error("Update Application failed. Domain is invalid")
+				return client.ErrInvalidValue
+			}
+			appUpdateReq.Domain = strconv.Itoa(value)
+
+			if appDomain == client.AppDomainCustom {
+				if err := expandAppCertificate(ctx, d, ec, appUpdateReq); err != nil {
+					ec.Logger.Error("Custom domain processing failed: ", err)
+					return err
+				}
+			}
+		}
+	} else {
+		appUpdateReq.Domain = strconv.Itoa(int(client.APP_DOMAIN_WAPP))
+	}
+
+	return nil
+}
+
+// expandACLService builds the access control service and its rules from the service attribute
+func expandACLService(d *schema.ResourceData, ec *client.EaaClient) (*client.ACLService, error) {
+	var aclAccessRules []client.AccessRule
+	var aclSrv client.ACLService
+
+	// Read services list from ResourceData
+	servicesRaw, ok := d.Get("service").([]interface{})
+	if !ok {
+		ec.Logger.Info("invalid service configuration")
+		return nil, fmt.Errorf("invalid service configuration")
+	}
+
+	// Iterate through each service
+	for _, svcRaw := range servicesRaw {
+		appSvc, ok := svcRaw.(map[string]interface{})
+		if !ok {
+			ec.Logger.Info("invalid service configuration.")
+			return nil, fmt.Errorf("invalid service configuration")
+		}
+
+		serviceType, ok := appSvc["service_type"].(string)
+		if !ok {
+			ec.Logger.Info("invalid or missing service_type.")
+			return nil, fmt.Errorf("invalid or missing service_type")
+		}
+
+		if serviceType != string(client.ServiceTypeAccessCtrl) {
+			continue
+		}
+
+		serviceStatus, ok := appSvc["status"].(string)
+		if !ok {
+			ec.Logger.Info("Invalid or missing service status.")
+			return nil, fmt.Errorf("invalid or missing service status")
+		}
+		aclSrv.Status = serviceStatus
+
+		// Extract access rules
+		accessRulesRaw, ok := appSvc["access_rule"].([]interface{})
+		if !ok {
+			ec.Logger.Info("invalid access_rule list")
+			return nil, fmt.Errorf("invalid access_rule list")
+		}
+
+		for _, accessRuleRaw := range accessRulesRaw {
+			accessRule, ok := accessRuleRaw.(map[string]interface{})
+			if !ok {
+				ec.Logger.Info("invalid access_rule configuration.")
+				return nil, fmt.Errorf("invalid access_rule configuration")
+			}
+
+			var rules []client.ACLSetting
+			rulesRaw, ok := accessRule["rule"].([]interface{})
+			if !ok {
+				ec.Logger.Info("Invalid rule list type.")
+				return nil, fmt.Errorf("invalid rule list")
+			}
+
+			for _, ruleRaw := range rulesRaw {
+				ruleMap, ok := ruleRaw.(map[string]interface{})
+				if !ok {
+					ec.Logger.Info("invalid rule configuration.")
+					return nil, fmt.Errorf("invalid rule configuration")
+				}
+
+				operator, ok := ruleMap["operator"].(string)
+				if !ok {
+					ec.Logger.Info("Invalid or missing rule operator.")
+					return nil, fmt.Errorf("invalid or missing rule operator")
+				}
+
+				ruleType, ok := ruleMap["type"].(string)
+				if !ok {
+					ec.Logger.Info("Invalid or missing rule type.")
+					return nil, fmt.Errorf("invalid or missing rule type")
+				}
+
+				value, ok := ruleMap["value"].(string)
+				if !ok {
+					ec.Logger.Info("invalid or missing rule value.")
+					return nil, fmt.Errorf("invalid or missing rule value")
+				}
+				rule := client.ACLSetting{
+					Operator: operator,
+					Type:     ruleType,
+					Value:    value,
+				}
+
+				if err := rule.Validate(); err != nil {
+					return nil, fmt.Errorf("invalid rule configuration")
+				}
+
+				rules = append(rules, rule)
+			}
+
+			name, ok := accessRule["name"].(string)
+			if !ok {
+				ec.Logger.Info("Invalid or missing access_rule name.")
+				return nil, fmt.Errorf("invalid or missing access_rule name")
+			}
+
+			status, ok := accessRule["status"].(string)
+			ruleStatus := client.ADMIN_STATE_DISABLED
+			if !ok || (status != client.RULE_ON && status != client.RULE_OFF) {
+				status = client.RULE_OFF
+			}
+			if status == client.RULE_ON {
+				ruleStatus = client.ADMIN_STATE_ENABLED
+			}
+
+			aclAccessRules = append(aclAccessRules, client.AccessRule{
+				Name:     name,
+				Settings: rules,
+				Status:   ruleStatus,
+			})
+		}
+	}
+	aclSrv.ACLRules = aclAccessRules
+	return &aclSrv, nil
+}
+
+// expandAppCertificate sets the certificate of an application served on a custom domain from cert_type and cert_name
+func expandAppCertificate(ctx context.Context, d *schema.ResourceData, ec *client.EaaClient, appUpdateReq *client.ApplicationUpdateRequest) error {
+	// Default certificate type to "self-signed"
+	certType := client.CertSelfSigned
+	if cert, ok := d.GetOk("cert_type"); ok {
+		certStr, ok := cert.(string)
+		if !ok {
+			return fmt.Errorf("cert_type is not a valid string")
+		}
+		certType = client.CertType(certStr)
+	}
+
+	host := ""
+	if appUpdateReq.Host != nil {
+		host = *appUpdateReq.Host
+	}
+	certName, _ := d.Get("cert_name").(string)
+
+	certUUID, err := client.GetAppCertificate(ctx, ec, certType, host, certName)
+	if err != nil {
+		return err
+	}
+	if certUUID != "" {
+		appUpdateReq.Cert = &certUUID
+	}
+	return nil
+}
+
+// expandDirectoryAssignments converts the app_directories of app_authentication
+func expandDirectoryAssignments(appDirs interface{}) []client.DirectoryAssignment {
+	var assignments []client.DirectoryAssignment
+	appDirsList, ok := appDirs.([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, s := range appDirsList {
+		sData, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dirName, ok := sData["name"].(string)
+		if !ok {
+			continue
+		}
+		assignment := client.DirectoryAssignment{Name: dirName}
+		if em, ok := sData["enable_mfa"].(bool); ok {
+			assignment.EnableMFA = &em
+		}
+		if appGroupsList, ok := sData["app_groups"].([]interface{}); ok {
+			for _, g := range appGroupsList {
+				gData, ok := g.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				group := client.GroupAssignment{}
+				group.Name, _ = gData["name"].(string)
+				if em, ok := gData["enable_mfa"].(string); ok {
+					group.EnableMFA = &em
+				}
+				assignment.Groups = append(assignment.Groups, group)
+			}
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
+
+// flattenAppAuthentication builds the app_authentication block.
+// Only the directories with assigned groups are reported, the others are not managed by the configuration.
+func flattenAppAuthentication(appAuth *client.AppAuthentication) []interface{} {
+	authData := make(map[string]interface{})
+	if appAuth == nil {
+		return []interface{}{authData}
+	}
+	authData["app_idp"] = appAuth.IDP
+
+	directoriesData := make([]map[string]interface{}, 0)
+	for _, dir := range appAuth.Directories {
+		if len(dir.Groups) == 0 {
+			continue
+		}
+		appGroups := make([]map[string]interface{}, 0, len(dir.Groups))
+		for _, group := range dir.Groups {
+			appGroups = append(appGroups, map[string]interface{}{"name": group.Name})
+		}
+		directoriesData = append(directoriesData, map[string]interface{}{
+			"name":       dir.Name,
+			"app_groups": appGroups,
+		})
+	}
+	// add "app_directories" key if the list is not empty
+	if len(directoriesData) > 0 {
+		authData["app_directories"] = directoriesData
+	}
+	return []interface{}{authData}
+}
+
+// flattenAppService builds the service block from the access control service and its rules,
+// nil when the service has no rule
+func flattenAppService(ctx context.Context, ec *client.EaaClient, appService *client.AppService) ([]interface{}, error) {
+	if appService.UUIDURL == "" {
+		ec.Logger.Error("flattenAppService failed. empty uuid_url")
+		return nil, fmt.Errorf("creating appservice struct failed. empty uuid_url")
+	}
+
+	response, err := client.GetAccessControlRules(ctx, ec, appService.UUIDURL)
+	if err != nil {
+		ec.Logger.Error("get access control rules failed. err", err)
+		return nil, err
+	}
+	if len(response.ACLRules) == 0 {
+		return nil, nil
+	}
+
+	appSvc := make(map[string]interface{})
+	appSvc["service_type"] = "access"
+	appSvc["status"] = appService.Status
+
+	var accessRules []map[string]interface{}
+	for _, aclRule := range response.ACLRules {
+		ruleStatus := client.RULE_OFF
+		if aclRule.Status == client.ADMIN_STATE_ENABLED {
+			ruleStatus = client.RULE_ON
+		}
+		rule := map[string]interface{}{
+			"name":   aclRule.Name,
+			"status": ruleStatus,
+		}
+
+		var rules []map[string]interface{}
+		for _, aclSetting := range aclRule.Settings {
+			rules = append(rules, map[string]interface{}{
+				"operator": aclSetting.Operator,
+				"type":     aclSetting.Type,
+				"value":    aclSetting.Value,
+			})
+		}
+		sort.SliceStable(rules, func(i, j int) bool {
+			return rules[i]["type"].(string) < rules[j]["type"].(string)
+		})
+		rule["rule"] = rules
+		accessRules = append(accessRules, rule)
+	}
+
+	sort.SliceStable(accessRules, func(i, j int) bool {
+		return accessRules[i]["name"].(string) < accessRules[j]["name"].(string)
+	})
+
+	appSvc["access_rule"] = accessRules
+	return []interface{}{appSvc}, nil
+}
+
+func setAttrs(d *schema.ResourceData, AttributeValues map[string]interface{}) error {
+	for attr, value := range AttributeValues {
+		if err := d.Set(attr, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
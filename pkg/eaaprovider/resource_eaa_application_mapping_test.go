@@ -0,0 +1,70 @@
+package eaaprovider
+
+import (
+	"errors"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandCreateAppRequest(t *testing.T) {
+	ec := &client.EaaClient{Logger: hclog.NewNullLogger()}
+
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, map[string]interface{}{
+		"name":        "app",
+		"description": "managed by terraform",
+		"app_profile": "http",
+		"app_type":    "enterprise",
+	})
+	car, err := expandCreateAppRequest(d, ec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if car.Name != "app" || car.Description == nil || *car.Description != "managed by terraform" {
+		t.Errorf("unexpected request %+v", car)
+	}
+	if car.AppProfile != int(client.APP_PROFILE_HTTP) || car.AppType != int(client.APP_TYPE_ENTERPRISE_HOSTED) || car.ClientAppMode != int(client.CLIENT_APP_MODE_TCP) {
+		t.Errorf("unexpected enums %+v", car)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, map[string]interface{}{
+		"name":     "app",
+		"app_type": "mainframe",
+	})
+	if _, err := expandCreateAppRequest(d, ec); !errors.Is(err, client.ErrInvalidValue) {
+		t.Errorf("err = %v, want %v", err, client.ErrInvalidValue)
+	}
+}
+
+func TestDirectoryAssignmentsRoundTrip(t *testing.T) {
+	appDirs := []interface{}{
+		map[string]interface{}{
+			"name":       "Cloud Directory",
+			"enable_mfa": "true",
+			"app_groups": []interface{}{
+				map[string]interface{}{"name": "Admins", "enable_mfa": "inherit"},
+			},
+		},
+		map[string]interface{}{"name": "AD", "app_groups": []interface{}{}},
+	}
+	assignments := expandDirectoryAssignments(appDirs)
+	if len(assignments) != 2 {
+		t.Fatalf("got %d assignments, want 2", len(assignments))
+	}
+	if len(assignments[0].Groups) != 1 || assignments[0].Groups[0].Name != "Admins" || *assignments[0].Groups[0].EnableMFA != "inherit" {
+		t.Errorf("unexpected groups %+v", assignments[0].Groups)
+	}
+	if len(assignments[1].Groups) != 0 {
+		t.Errorf("AD should assign every group, got %+v", assignments[1].Groups)
+	}
+
+	flat := flattenAppAuthentication(&client.AppAuthentication{IDP: "idp", Directories: assignments})
+	auth := flat[0].(map[string]interface{})
+	dirs := auth["app_directories"].([]map[string]interface{})
+	if auth["app_idp"] != "idp" || len(dirs) != 1 || dirs[0]["name"] != "Cloud Directory" {
+		t.Errorf("unexpected app_authentication %v", auth)
+	}
+}
@@ -0,0 +1,263 @@
+package eaaprovider
+
+import (
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const APP_AUTH_OIDC = "OpenID Connect 1.0"
+
+var (
+	oidcClientTypes   = []string{"confidential", "public"}
+	oidcResponseTypes = []string{"code", "id_token", "token"}
+)
+
+// oidcEndpoints maps the computed attributes of the oidc block to the endpoints generated by EAA
+var oidcEndpoints = map[string]func(*client.OIDCSettings) string{
+	"discovery_url":          func(s *client.OIDCSettings) string { return s.DiscoveryURL },
+	"authorization_endpoint": func(s *client.OIDCSettings) string { return s.AuthorizationEndpoint },
+	"token_endpoint":         func(s *client.OIDCSettings) string { return s.TokenEndpoint },
+	"userinfo_endpoint":      func(s *client.OIDCSettings) string { return s.UserinfoEndpoint },
+	"jwks_uri":               func(s *client.OIDCSettings) string { return s.JWKSURI },
+	"certs_uri":              func(s *client.OIDCSettings) string { return s.CertsURI },
+	"end_session_endpoint":   func(s *client.OIDCSettings) string { return s.EndSessionEndpoint },
+	"check_session_iframe":   func(s *client.OIDCSettings) string { return s.CheckSessionIframe },
+	"openid_metadata":        func(s *client.OIDCSettings) string { return s.OpenIDMetadata },
+}
+
+func oidcURLList(description string, required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    required,
+		Optional:    !required,
+		Description: description,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+		},
+	}
+}
+
+func oidcSchema() *schema.Schema {
+	attrs := map[string]*schema.Schema{
+		"client_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Name of the relying party, the name of the application by default",
+		},
+		"client_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "confidential",
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(oidcClientTypes, false)),
+			Description:      "confidential for relying parties that keep the client secret, public otherwise",
+		},
+		"response_types": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			Description: "Response types the relying party requests: code, id_token or token",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(oidcResponseTypes, false)),
+			},
+		},
+		"implicit_grant": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the implicit flow is allowed",
+		},
+		"redirect_uris":             oidcURLList("URIs the authorization responses are redirected to", true),
+		"post_logout_redirect_uris": oidcURLList("URIs the user is redirected to after logging out", false),
+		"javascript_origins":        oidcURLList("Origins of the browser applications allowed to call the endpoints", false),
+		"scopes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			Description: "Scopes the relying party can request, e.g. openid, profile and email",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"claim": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Claims added to the tokens",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Name of the claim",
+					},
+					"scope": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "openid",
+						Description: "Scope the claim is released with",
+					},
+					"src": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "User attribute the value is taken from, e.g. user.email",
+					},
+					"val": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Fixed value, used instead of src",
+					},
+					"rule": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Expression the value is computed with",
+					},
+				},
+			},
+		},
+		"client_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Client ID generated by EAA",
+		},
+		"client_secret": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Client secret generated by EAA",
+		},
+	}
+	for name := range oidcEndpoints {
+		attrs[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Generated by EAA",
+		}
+	}
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"saml", "wsfed"},
+		Description:   "OpenID Connect single sign-on, EAA acts as the OpenID provider of the application",
+		Elem:          &schema.Resource{Schema: attrs},
+	}
+}
+
+// expandOIDC copies the oidc block into appUpdateReq. The endpoints and credentials generated by EAA are kept from the application.
+func expandOIDC(d *schema.ResourceData, appUpdateReq *client.ApplicationUpdateRequest) error {
+	oidc := firstBlock(d.Get("oidc"))
+	if oidc == nil {
+		if d.HasChange("oidc") {
+			appUpdateReq.Oidc = false
+			resetAppAuth(appUpdateReq, APP_AUTH_OIDC)
+		}
+		return nil
+	}
+
+	settings := client.OIDCSettings{}
+	if appUpdateReq.OIDCSettings != nil {
+		settings = *appUpdateReq.OIDCSettings
+	}
+	rp := client.OIDCClient{}
+	if len(settings.OIDCClients) > 0 {
+		rp = settings.OIDCClients[0]
+	}
+
+	rp.ClientName = appUpdateReq.Name
+	if name := oidc["client_name"].(string); name != "" {
+		rp.ClientName = name
+	}
+	rp.Type = oidc["client_type"].(string)
+	if responseTypes := stringList(oidc["response_types"]); len(responseTypes) > 0 {
+		rp.ResponseType = responseTypes
+	} else if len(rp.ResponseType) == 0 {
+		rp.ResponseType = []string{"code"}
+	}
+	rp.ImplicitGrant = oidc["implicit_grant"].(bool)
+	rp.RedirectURIs = stringList(oidc["redirect_uris"])
+	rp.PostLogoutRedirectURIs = stringList(oidc["post_logout_redirect_uris"])
+	rp.JavaScriptOrigins = stringList(oidc["javascript_origins"])
+	if scopes := stringList(oidc["scopes"]); len(scopes) > 0 {
+		rp.Scopes = scopes
+	}
+
+	rp.Claims = []client.OIDCClaim{}
+	claims, _ := oidc["claim"].([]interface{})
+	for _, c := range claims {
+		claim, ok := c.(map[string]interface{})
+		if !ok {
+			return ErrInvalidData
+		}
+		rp.Claims = append(rp.Claims, client.OIDCClaim{
+			Name:  claim["name"].(string),
+			Scope: claim["scope"].(string),
+			Src:   claim["src"].(string),
+			Val:   claim["val"].(string),
+			Rule:  claim["rule"].(string),
+		})
+	}
+
+	settings.OIDCClients = []client.OIDCClient{rp}
+	appUpdateReq.Oidc = true
+	appUpdateReq.OIDCSettings = &settings
+	appUpdateReq.AdvancedSettings.AppAuth = APP_AUTH_OIDC
+	return nil
+}
+
+// flattenOIDC returns the oidc block of an application, it is empty unless OIDC is enabled.
+// The client secret is kept from the state when the API does not return it.
+func flattenOIDC(d *schema.ResourceData, app *client.ApplicationDataModel) []interface{} {
+	if !app.Oidc || app.OIDCSettings == nil {
+		return nil
+	}
+	settings := app.OIDCSettings
+	rp := client.OIDCClient{}
+	if len(settings.OIDCClients) > 0 {
+		rp = settings.OIDCClients[0]
+	}
+
+	claims := make([]interface{}, len(rp.Claims))
+	for i, claim := range rp.Claims {
+		claims[i] = map[string]interface{}{
+			"name":  claim.Name,
+			"scope": claim.Scope,
+			"src":   claim.Src,
+			"val":   claim.Val,
+			"rule":  claim.Rule,
+		}
+	}
+	secret := rp.ClientSecret
+	if secret == "" {
+		secret, _ = d.Get("oidc.0.client_secret").(string)
+	}
+	block := map[string]interface{}{
+		"client_name":               rp.ClientName,
+		"client_type":               rp.Type,
+		"response_types":            rp.ResponseType,
+		"implicit_grant":            rp.ImplicitGrant,
+		"redirect_uris":             rp.RedirectURIs,
+		"post_logout_redirect_uris": rp.PostLogoutRedirectURIs,
+		"javascript_origins":        rp.JavaScriptOrigins,
+		"scopes":                    rp.Scopes,
+		"claim":                     claims,
+		"client_id":                 rp.ClientID,
+		"client_secret":             secret,
+	}
+	for name, endpoint := range oidcEndpoints {
+		block[name] = endpoint(settings)
+	}
+	return []interface{}{block}
+}
+
+// stringList converts a list attribute to a slice of strings, it is never nil so that empty lists are sent as []
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,98 @@
+package eaaprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationOIDC(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	config := testApplicationConfig("tf-oidc-app")
+	config["app_type"] = "saas"
+	config["oidc"] = []interface{}{
+		map[string]interface{}{
+			"redirect_uris":  []interface{}{"https://rp.example.com/callback"},
+			"response_types": []interface{}{"code", "id_token"},
+			"claim": []interface{}{
+				map[string]interface{}{"name": "email", "scope": "email", "src": "user.email"},
+				map[string]interface{}{"name": "groups", "src": "user.groups"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	if !app.Oidc || app.OIDCSettings == nil || len(app.OIDCSettings.OIDCClients) != 1 {
+		t.Fatalf("oidc = %t, oidc_settings = %+v", app.Oidc, app.OIDCSettings)
+	}
+	if app.AdvancedSettings.AppAuth != APP_AUTH_OIDC {
+		t.Errorf("app_auth = %q, want %q", app.AdvancedSettings.AppAuth, APP_AUTH_OIDC)
+	}
+	rp := app.OIDCSettings.OIDCClients[0]
+	if rp.ClientName != "tf-oidc-app" || rp.Type != "confidential" || len(rp.ResponseType) != 2 {
+		t.Errorf("sent client = %+v", rp)
+	}
+	if len(rp.Claims) != 2 || rp.Claims[1].Scope != "openid" {
+		t.Errorf("sent claims = %+v", rp.Claims)
+	}
+
+	checks := map[string]string{
+		"oidc.#":                      "1",
+		"oidc.0.client_name":          "tf-oidc-app",
+		"oidc.0.redirect_uris.0":      "https://rp.example.com/callback",
+		"oidc.0.scopes.#":             "3",
+		"oidc.0.claim.0.scope":        "email",
+		"oidc.0.client_id":            rp.ClientID,
+		"oidc.0.client_secret":        rp.ClientSecret,
+		"oidc.0.discovery_url":        app.OIDCSettings.DiscoveryURL,
+		"oidc.0.token_endpoint":       app.OIDCSettings.TokenEndpoint,
+		"oidc.0.end_session_endpoint": app.OIDCSettings.EndSessionEndpoint,
+	}
+	for key, want := range checks {
+		if got := fmt.Sprint(d.Get(key)); got != want || got == "" {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if !resourceEaaApplication().Schema["oidc"].Elem.(*schema.Resource).Schema["client_secret"].Sensitive {
+		t.Error("client_secret is not sensitive")
+	}
+}
+
+func TestEaaApplicationOIDCUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	oidc := `
+  oidc {
+    redirect_uris = ["https://rp.example.com/callback"]
+  }
+`
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "saas", oidc),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_OIDC),
+			},
+			{
+				Config: testUnitAppConfig("http", "saas", ""),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_NONE),
+			},
+			{
+				Config:      testUnitAppConfig("http", "enterprise", oidc),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`oidc is only valid when app_type is "saas"`),
+			},
+		},
+	})
+}
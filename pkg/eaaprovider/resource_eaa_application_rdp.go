@@ -0,0 +1,57 @@
+package eaaprovider
+
+import (
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rdpSettings are the settings of the rdp_settings block, the remote_spark settings control the HTML5 RDP client
+var rdpSettings = []advancedSetting{
+	{Name: "rdp_initial_program", Attr: "initial_program", Kind: settingString, Description: "Program started when the session opens instead of the desktop"},
+	{Name: "rdp_legacy_mode", Attr: "legacy_mode", Kind: settingBool, Description: "Whether the legacy RDP security layer is used"},
+	{Name: "rdp_tls1", Attr: "tls1", Kind: settingBool, Description: "Whether TLS 1.0 is accepted by the RDP server"},
+	{Name: "remote_spark_mapClipboard", Attr: "clipboard", Kind: settingSwitch, Description: "Whether the clipboard is shared with the remote desktop"},
+	{Name: "remote_spark_audio", Attr: "audio", Kind: settingBool, Description: "Whether the remote audio is played locally"},
+	{Name: "remote_spark_mapPrinter", Attr: "map_printer", Kind: settingSwitch, Description: "Whether a local printer is mapped to the remote desktop"},
+	{Name: "remote_spark_printer", Attr: "printer_name", Kind: settingString, Description: "Name of the printer mapped to the remote desktop"},
+	{Name: "remote_spark_mapDisk", Attr: "map_disk", Kind: settingSwitch, Description: "Whether a local disk is mapped to the remote desktop"},
+	{Name: "remote_spark_disk", Attr: "disk_name", Kind: settingString, Description: "Name of the disk mapped to the remote desktop"},
+	{Name: "remote_spark_recording", Attr: "recording", Kind: settingBool, Description: "Whether the sessions are recorded"},
+}
+
+func rdpSettingsSchema() *schema.Schema {
+	block := settingsBlockSchema(rdpSettings)
+	// the RDP version is an attribute of the application, not an advanced setting
+	block.Elem.(*schema.Resource).Schema["version"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "RDP protocol version of the server",
+	}
+	return block
+}
+
+// expandRDPSettings copies the rdp_settings block into appUpdateReq
+func expandRDPSettings(d *schema.ResourceData, appUpdateReq *client.ApplicationUpdateRequest) error {
+	if err := expandSettingsBlock(d, "rdp_settings", rdpSettings, &appUpdateReq.AdvancedSettings); err != nil {
+		return err
+	}
+	if version, ok := d.GetOk("rdp_settings.0.version"); ok {
+		appUpdateReq.RDPVersion = version.(string)
+	}
+	return nil
+}
+
+// flattenRDPSettings returns the rdp_settings block of an application, it is empty unless app_profile is rdp
+func flattenRDPSettings(app *client.ApplicationDataModel) ([]interface{}, error) {
+	if client.AppProfileInt(app.AppProfile) != client.APP_PROFILE_RDP {
+		return nil, nil
+	}
+	block, err := flattenSettingsBlock(rdpSettings, &app.AdvancedSettings)
+	if err != nil {
+		return nil, err
+	}
+	block[0].(map[string]interface{})["version"] = app.RDPVersion
+	return block, nil
+}
@@ -0,0 +1,121 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationRDPSettings(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	config := testApplicationConfig("tf-rdp-app", "terraform-test-connector")
+	config["app_profile"] = "rdp"
+	config["rdp_settings"] = []interface{}{
+		map[string]interface{}{
+			"version":         "8",
+			"initial_program": "notepad.exe",
+			"clipboard":       true,
+			"map_disk":        true,
+			"disk_name":       "TSDisk",
+			"recording":       false,
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	if app.RDPVersion != "8" {
+		t.Errorf("rdp_version = %q, want 8", app.RDPVersion)
+	}
+	sent := map[string]string{
+		"rdp_initial_program":       "notepad.exe",
+		"remote_spark_mapClipboard": client.STR_ON,
+		"remote_spark_mapDisk":      client.STR_ON,
+		"remote_spark_disk":         "TSDisk",
+	}
+	for key, want := range sent {
+		if got, _ := app.AdvancedSettings.Get(key); got != want {
+			t.Errorf("sent %s = %q, want %q", key, got, want)
+		}
+	}
+
+	checks := map[string]string{
+		"rdp_settings.#":                 "1",
+		"rdp_settings.0.version":         "8",
+		"rdp_settings.0.initial_program": "notepad.exe",
+		"rdp_settings.0.clipboard":       "true",
+		"rdp_settings.0.map_printer":     "false",
+		"rdp_settings.0.disk_name":       "TSDisk",
+	}
+	for key, want := range checks {
+		if got := fmt.Sprint(d.Get(key)); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	// other profiles have no rdp_settings
+	d = schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig("tf-http-app", "terraform-test-connector"))
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if got := d.Get("rdp_settings.#"); got != 0 {
+		t.Errorf("rdp_settings.# = %v, want 0", got)
+	}
+}
+
+func TestEaaApplicationRDPSettingsPlan(t *testing.T) {
+	srv := newTestAPI(t)
+	tf := newTestTerraform(t, srv)
+
+	config := testApplicationConfig("tf-rdp-app", "terraform-test-connector")
+	config["app_profile"] = "rdp"
+	config["rdp_settings"] = []interface{}{
+		map[string]interface{}{
+			"initial_program": "notepad.exe",
+			"clipboard":       true,
+		},
+	}
+	tf.applyAndReplan(config)
+
+	config["app_profile"] = "http"
+	if _, err := tf.plan(config); !errors.Is(err, ErrInvalidAppConfig) {
+		t.Errorf("err = %v, want rdp_settings on an http app reported", err)
+	}
+}
+
+func TestEaaApplicationRDPSettingsUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	rdpSettings := `
+  rdp_settings {
+    initial_program = "notepad.exe"
+    clipboard       = true
+  }
+`
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("rdp", "enterprise", rdpSettings),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "rdp_settings.0.clipboard", "true"),
+			},
+			{
+				Config:      testUnitAppConfig("http", "enterprise", rdpSettings),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rdp_settings is only valid when app_profile is "rdp"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,345 @@
+package eaaprovider
+
+import (
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const APP_AUTH_SAML = "SAML2.0"
+
+var (
+	samlBindings      = []string{"redirect", "post"}
+	samlEncryptAlgos  = []string{"aes128-cbc", "aes256-cbc"}
+	samlSignAlgos     = []string{"SHA1", "SHA256"}
+	samlNameIDFormats = []string{"email", "persistent", "transient", "unspecified"}
+	samlAttrFormats   = []string{"basic", "uri", "unspecified"}
+)
+
+// samlString is a string attribute of the saml block, an empty values list accepts any value
+func samlString(description, defaultValue string, values ...string) *schema.Schema {
+	s := &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: description,
+	}
+	if defaultValue != "" {
+		s.Default = defaultValue
+	}
+	if len(values) > 0 {
+		s.ValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(values, false))
+	}
+	return s
+}
+
+func samlBool(description string, defaultValue bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     defaultValue,
+		Description: description,
+	}
+}
+
+func samlComputed(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: description,
+	}
+}
+
+// subjectSchema is the NameID of the assertions of the saml and wsfed blocks
+func subjectSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: "NameID of the assertions",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"fmt":  samlString("NameID format: email, persistent, transient or unspecified", "email", samlNameIDFormats...),
+				"src":  samlString("User attribute the NameID is taken from, e.g. user.email", "user.email"),
+				"val":  samlString("Fixed NameID, used instead of src", ""),
+				"rule": samlString("Expression the NameID is computed with", ""),
+			},
+		},
+	}
+}
+
+func samlSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"oidc", "wsfed"},
+		Description:   "SAML single sign-on of a SaaS application, EAA acts as the IdP of the service provider",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"sp": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Description: "The service provider, i.e. the SaaS application",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"entity_id": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Entity ID of the service provider",
+							},
+							"acs_url": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+								Description:      "Assertion consumer service URL the responses are posted to",
+							},
+							"slo_url":             samlString("Single logout URL of the service provider", ""),
+							"req_bind":            samlString("Binding of the authentication requests: redirect or post", "redirect", samlBindings...),
+							"slo_bind":            samlString("Binding of the logout requests: redirect or post", "post", samlBindings...),
+							"default_relay_state": samlString("Relay state sent with IdP initiated logins", ""),
+							"dst_url":             samlString("Destination of the responses when it differs from acs_url", ""),
+							"metadata":            samlString("Metadata XML of the service provider", ""),
+							"force_auth":          samlBool("Whether the user authenticates again even with a valid session", false),
+							"req_verify":          samlBool("Whether the signature of the authentication requests is verified with sign_cert", false),
+							"slo_req_verify":      samlBool("Whether the signature of the logout requests is verified with sign_cert", false),
+							"sign_cert":           samlString("PEM certificate the service provider signs its requests with", ""),
+							"resp_encr":           samlBool("Whether the assertions are encrypted with encr_cert", false),
+							"encr_cert":           samlString("PEM certificate the assertions are encrypted for", ""),
+							"encr_algo":           samlString("Encryption of the assertions: aes128-cbc or aes256-cbc", "aes256-cbc", samlEncryptAlgos...),
+						},
+					},
+				},
+				"idp": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					MaxItems:    1,
+					Description: "The identity provider, i.e. EAA",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"sign_algo":          samlString("Signature algorithm of the responses: SHA1 or SHA256", "SHA256", samlSignAlgos...),
+							"resp_bind":          samlString("Binding of the responses: redirect or post", "post", samlBindings...),
+							"self_signed":        samlBool("Whether the responses are signed with a certificate generated by EAA", true),
+							"ecp_enable":         samlBool("Whether the enhanced client or proxy profile is enabled", false),
+							"ecp_resp_signature": samlBool("Whether the ECP responses are signed", false),
+							"sign_cert": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Computed:    true,
+								Description: "PEM certificate the responses are signed with, generated by EAA when self_signed is true",
+							},
+							"entity_id": samlComputed("Entity ID of the IdP"),
+							"slo_url":   samlComputed("Single logout URL of the IdP"),
+						},
+					},
+				},
+				"subject": subjectSchema(),
+				"attribute": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Attributes added to the assertions",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Name of the attribute in the assertion",
+							},
+							"fname": samlString("Friendly name of the attribute", ""),
+							"fmt":   samlString("Name format of the attribute: basic, uri or unspecified", "basic", samlAttrFormats...),
+							"src":   samlString("User attribute the value is taken from, e.g. user.firstName", ""),
+							"val":   samlString("Fixed value, used instead of src", ""),
+							"rule":  samlString("Expression the value is computed with", ""),
+						},
+					},
+				},
+				"idp_metadata": samlComputed("Metadata XML of the IdP to hand to the service provider"),
+			},
+		},
+	}
+}
+
+// defaultSAMLConfig is the configuration EAA gives a SaaS application
+func defaultSAMLConfig() client.SAMLConfig {
+	return client.SAMLConfig{
+		SP:      client.SPProperties{ReqBind: "redirect", SLOBind: "post", EncrAlgo: "aes256-cbc"},
+		IDP:     client.IDPProperties{SelfSigned: true, SignAlgo: "SHA256", RespBind: "post"},
+		Subject: client.SubjectProperties{Fmt: "email", Src: "user.email"},
+		Attrmap: []client.AttrMapping{},
+	}
+}
+
+// firstBlock returns the attributes of a single nested block, nil when it is not set
+func firstBlock(v interface{}) map[string]interface{} {
+	list, _ := v.([]interface{})
+	if len(list) == 0 {
+		return nil
+	}
+	block, _ := list[0].(map[string]interface{})
+	return block
+}
+
+// expandSAML copies the saml block into appUpdateReq. The IdP values generated by EAA are kept from the application.
+func expandSAML(d *schema.ResourceData, appUpdateReq *client.ApplicationUpdateRequest) error {
+	saml := firstBlock(d.Get("saml"))
+	if saml == nil {
+		if d.HasChange("saml") {
+			appUpdateReq.SAML = false
+			resetAppAuth(appUpdateReq, APP_AUTH_SAML)
+		}
+		return nil
+	}
+
+	config := defaultSAMLConfig()
+	if len(appUpdateReq.SAMLSettings) > 0 {
+		config = appUpdateReq.SAMLSettings[0]
+	}
+
+	sp := firstBlock(saml["sp"])
+	if sp == nil {
+		return ErrInvalidData
+	}
+	config.SP.EntityID = sp["entity_id"].(string)
+	config.SP.ACSURL = sp["acs_url"].(string)
+	config.SP.SLOURL = sp["slo_url"].(string)
+	config.SP.ReqBind = sp["req_bind"].(string)
+	config.SP.SLOBind = sp["slo_bind"].(string)
+	config.SP.DefaultRelayState = nil
+	if relayState := sp["default_relay_state"].(string); relayState != "" {
+		config.SP.DefaultRelayState = &relayState
+	}
+	config.SP.DSTURL = sp["dst_url"].(string)
+	config.SP.Metadata = sp["metadata"].(string)
+	config.SP.ForceAuth = sp["force_auth"].(bool)
+	config.SP.ReqVerify = sp["req_verify"].(bool)
+	config.SP.SLOReqVerify = sp["slo_req_verify"].(bool)
+	config.SP.SignCert = sp["sign_cert"].(string)
+	config.SP.RespEncr = sp["resp_encr"].(bool)
+	config.SP.EncrCert = sp["encr_cert"].(string)
+	config.SP.EncrAlgo = sp["encr_algo"].(string)
+
+	if idp := firstBlock(saml["idp"]); idp != nil {
+		config.IDP.SignAlgo = idp["sign_algo"].(string)
+		config.IDP.RespBind = idp["resp_bind"].(string)
+		config.IDP.SelfSigned = idp["self_signed"].(bool)
+		config.IDP.ECPIsEnabled = idp["ecp_enable"].(bool)
+		config.IDP.ECPRespSignature = idp["ecp_resp_signature"].(bool)
+		if cert := idp["sign_cert"].(string); cert != "" {
+			config.IDP.SignCert = cert
+		}
+	}
+
+	if subject := firstBlock(saml["subject"]); subject != nil {
+		config.Subject = expandSubject(subject)
+	}
+
+	config.Attrmap = []client.AttrMapping{}
+	attributes, _ := saml["attribute"].([]interface{})
+	for _, a := range attributes {
+		attr, ok := a.(map[string]interface{})
+		if !ok {
+			return ErrInvalidData
+		}
+		config.Attrmap = append(config.Attrmap, client.AttrMapping{
+			Name:  attr["name"].(string),
+			Fname: attr["fname"].(string),
+			Fmt:   attr["fmt"].(string),
+			Src:   attr["src"].(string),
+			Val:   attr["val"].(string),
+			Rule:  attr["rule"].(string),
+		})
+	}
+
+	appUpdateReq.SAML = true
+	appUpdateReq.SAMLSettings = []client.SAMLConfig{config}
+	appUpdateReq.AdvancedSettings.AppAuth = APP_AUTH_SAML
+	return nil
+}
+
+// resetAppAuth sets app_auth back to none when single sign-on with method is disabled,
+// unless the kerberos block already set another method
+func resetAppAuth(appUpdateReq *client.ApplicationUpdateRequest, method string) {
+	if appUpdateReq.AdvancedSettings.AppAuth == method {
+		appUpdateReq.AdvancedSettings.AppAuth = APP_AUTH_NONE
+	}
+}
+
+func expandSubject(subject map[string]interface{}) client.SubjectProperties {
+	return client.SubjectProperties{
+		Fmt:  subject["fmt"].(string),
+		Src:  subject["src"].(string),
+		Val:  subject["val"].(string),
+		Rule: subject["rule"].(string),
+	}
+}
+
+func flattenSubject(subject client.SubjectProperties) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"fmt":  subject.Fmt,
+		"src":  subject.Src,
+		"val":  subject.Val,
+		"rule": subject.Rule,
+	}}
+}
+
+// flattenSAML returns the saml block of an application, it is empty unless SAML is enabled
+func flattenSAML(app *client.ApplicationDataModel) []interface{} {
+	if !app.SAML || len(app.SAMLSettings) == 0 {
+		return nil
+	}
+	config := app.SAMLSettings[0]
+
+	relayState := ""
+	if config.SP.DefaultRelayState != nil {
+		relayState = *config.SP.DefaultRelayState
+	}
+	sp := map[string]interface{}{
+		"entity_id":           config.SP.EntityID,
+		"acs_url":             config.SP.ACSURL,
+		"slo_url":             config.SP.SLOURL,
+		"req_bind":            config.SP.ReqBind,
+		"slo_bind":            config.SP.SLOBind,
+		"default_relay_state": relayState,
+		"dst_url":             config.SP.DSTURL,
+		"metadata":            config.SP.Metadata,
+		"force_auth":          config.SP.ForceAuth,
+		"req_verify":          config.SP.ReqVerify,
+		"slo_req_verify":      config.SP.SLOReqVerify,
+		"sign_cert":           config.SP.SignCert,
+		"resp_encr":           config.SP.RespEncr,
+		"encr_cert":           config.SP.EncrCert,
+		"encr_algo":           config.SP.EncrAlgo,
+	}
+	idp := map[string]interface{}{
+		"sign_algo":          config.IDP.SignAlgo,
+		"resp_bind":          config.IDP.RespBind,
+		"self_signed":        config.IDP.SelfSigned,
+		"ecp_enable":         config.IDP.ECPIsEnabled,
+		"ecp_resp_signature": config.IDP.ECPRespSignature,
+		"sign_cert":          config.IDP.SignCert,
+		"entity_id":          config.IDP.EntityID,
+		"slo_url":            config.IDP.SLOURL,
+	}
+	attributes := make([]interface{}, len(config.Attrmap))
+	for i, attr := range config.Attrmap {
+		attributes[i] = map[string]interface{}{
+			"name":  attr.Name,
+			"fname": attr.Fname,
+			"fmt":   attr.Fmt,
+			"src":   attr.Src,
+			"val":   attr.Val,
+			"rule":  attr.Rule,
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"sp":           []interface{}{sp},
+		"idp":          []interface{}{idp},
+		"subject":      flattenSubject(config.Subject),
+		"attribute":    attributes,
+		"idp_metadata": config.IDP.Metadata,
+	}}
+}
@@ -0,0 +1,205 @@
+package eaaprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationSAML(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+	ec := meta.EaaClient
+
+	config := testApplicationConfig("tf-saml-app")
+	config["app_type"] = "saas"
+	config["saml"] = []interface{}{
+		map[string]interface{}{
+			"sp": []interface{}{
+				map[string]interface{}{
+					"entity_id":           "https://sp.example.com",
+					"acs_url":             "https://sp.example.com/saml/acs",
+					"slo_url":             "https://sp.example.com/saml/slo",
+					"req_bind":            "post",
+					"default_relay_state": "/home",
+				},
+			},
+			"subject": []interface{}{
+				map[string]interface{}{"fmt": "persistent", "src": "user.userPrincipleName"},
+			},
+			"attribute": []interface{}{
+				map[string]interface{}{"name": "firstName", "src": "user.firstName"},
+				map[string]interface{}{"name": "department", "fmt": "uri", "val": "engineering"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	if !app.SAML || len(app.SAMLSettings) != 1 {
+		t.Fatalf("saml = %t, saml_settings = %v", app.SAML, app.SAMLSettings)
+	}
+	if app.AdvancedSettings.AppAuth != APP_AUTH_SAML {
+		t.Errorf("app_auth = %q, want %q", app.AdvancedSettings.AppAuth, APP_AUTH_SAML)
+	}
+	sent := app.SAMLSettings[0]
+	if sent.SP.ACSURL != "https://sp.example.com/saml/acs" || sent.SP.ReqBind != "post" || sent.SP.EncrAlgo != "aes256-cbc" {
+		t.Errorf("sent sp = %+v", sent.SP)
+	}
+	if sent.SP.DefaultRelayState == nil || *sent.SP.DefaultRelayState != "/home" {
+		t.Errorf("sent default_relay_state = %v, want /home", sent.SP.DefaultRelayState)
+	}
+	if sent.Subject.Fmt != "persistent" || sent.Subject.Src != "user.userPrincipleName" {
+		t.Errorf("sent subject = %+v", sent.Subject)
+	}
+	if len(sent.Attrmap) != 2 || sent.Attrmap[1].Val != "engineering" || sent.Attrmap[1].Fmt != "uri" {
+		t.Errorf("sent attrmap = %+v", sent.Attrmap)
+	}
+
+	checks := map[string]string{
+		"saml.#":                 "1",
+		"saml.0.sp.0.entity_id":  "https://sp.example.com",
+		"saml.0.sp.0.slo_bind":   "post",
+		"saml.0.idp.0.sign_algo": "SHA256",
+		"saml.0.idp.0.entity_id": sent.IDP.EntityID,
+		"saml.0.subject.0.fmt":   "persistent",
+		"saml.0.attribute.#":     "2",
+		"saml.0.attribute.0.fmt": "basic",
+	}
+	for key, want := range checks {
+		if got := fmt.Sprint(d.Get(key)); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	metadata := d.Get("saml.0.idp_metadata").(string)
+	if sent.IDP.EntityID == "" || !strings.Contains(metadata, sent.IDP.EntityID) {
+		t.Errorf("idp_metadata = %q, want the metadata of %q", metadata, sent.IDP.EntityID)
+	}
+
+	// an update keeps the IdP generated by EAA
+	appData, err := client.GetApplication(ctx, ec, d.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	appUpdateReq := client.ApplicationUpdateRequest{Application: appData.Application, AdvancedSettings: appData.AdvancedSettings}
+	if err := expandUpdateAppRequest(ctx, d, ec, &appUpdateReq); err != nil {
+		t.Fatal(err)
+	}
+	if got := appUpdateReq.SAMLSettings[0].IDP; got.EntityID != sent.IDP.EntityID || got.SignCert != sent.IDP.SignCert {
+		t.Errorf("update idp = %+v, want %+v", got, sent.IDP)
+	}
+}
+
+func TestEaaApplicationSSORemoval(t *testing.T) {
+	blocks := map[string]struct {
+		settings map[string]interface{}
+		appAuth  string
+	}{
+		"saml": {
+			settings: map[string]interface{}{
+				"sp": []interface{}{
+					map[string]interface{}{"entity_id": "https://sp.example.com", "acs_url": "https://sp.example.com/saml/acs"},
+				},
+			},
+			appAuth: APP_AUTH_SAML,
+		},
+		"oidc": {
+			settings: map[string]interface{}{"redirect_uris": []interface{}{"https://rp.example.com/callback"}},
+			appAuth:  APP_AUTH_OIDC,
+		},
+		"wsfed": {
+			settings: map[string]interface{}{"realm": "urn:app:payroll", "reply_url": "https://payroll.example.com/"},
+			appAuth:  APP_AUTH_WSFED,
+		},
+	}
+	// the kerberos block that replaces the single sign-on block and the app_auth it leaves
+	replacements := map[string]struct {
+		kerberos map[string]interface{}
+		appAuth  string
+	}{
+		"removed": {appAuth: APP_AUTH_NONE},
+		"replaced by kerberos": {
+			kerberos: map[string]interface{}{"app_auth": APP_AUTH_KERBEROS, "service_principal_name": "HTTP/app.example.com"},
+			appAuth:  APP_AUTH_KERBEROS,
+		},
+	}
+	for block, sso := range blocks {
+		for name, replacement := range replacements {
+			t.Run(block+" "+name, func(t *testing.T) {
+				srv := newTestAPI(t)
+				tf := newTestTerraform(t, srv)
+
+				config := testApplicationConfig("tf-sso-app")
+				config["app_type"] = "saas"
+				config[block] = []interface{}{sso.settings}
+				tf.applyAndReplan(config)
+				if app, _ := srv.App(tf.state.ID); app.AdvancedSettings.AppAuth != sso.appAuth {
+					t.Fatalf("app_auth = %q, want %q", app.AdvancedSettings.AppAuth, sso.appAuth)
+				}
+
+				delete(config, block)
+				if replacement.kerberos != nil {
+					config["kerberos"] = []interface{}{replacement.kerberos}
+				}
+				tf.applyAndReplan(config)
+				if app, _ := srv.App(tf.state.ID); app.AdvancedSettings.AppAuth != replacement.appAuth {
+					t.Errorf("app_auth = %q, want %q", app.AdvancedSettings.AppAuth, replacement.appAuth)
+				}
+				if got := tf.state.Attributes["kerberos.0.app_auth"]; got != replacement.appAuth {
+					t.Errorf("kerberos.0.app_auth = %q, want %q", got, replacement.appAuth)
+				}
+			})
+		}
+	}
+}
+
+func TestEaaApplicationSAMLUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	saml := `
+  saml {
+    sp {
+      entity_id = "https://sp.example.com"
+      acs_url   = "https://sp.example.com/saml/acs"
+    }
+  }
+`
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "saas", saml),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_SAML),
+			},
+			{
+				Config: testUnitAppConfig("http", "saas", ""),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_NONE),
+			},
+			{
+				Config:      testUnitAppConfig("http", "enterprise", saml),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`saml is only valid when app_type is "saas"`),
+			},
+			{
+				Config: testUnitAppConfig("http", "saas", saml+`
+  kerberos {
+    app_auth = "NTLMv2"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("kerberos app_auth cannot be set with saml"),
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package eaaprovider
+
+import (
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// sshSettings are the settings of the ssh_settings block that are stored in the state as they are
+var sshSettings = []advancedSetting{
+	{Name: "user_name", Kind: settingString, Description: "User the connector logs in as on the SSH server"},
+	{Name: "host_key", Kind: settingString, Description: "Public host key expected from the SSH server"},
+	{Name: "ssh_audit_enabled", Attr: "audit_enabled", Kind: settingBool, Description: "Whether the SSH sessions are audited"},
+}
+
+// sshSecrets are the key material of the ssh_settings block, the state only keeps their SHA-256
+// so they are sent again only when the configured value changes
+var sshSecrets = map[string]string{
+	"private_key": "Private key the connector logs in with, in PEM format",
+	"pass_phrase": "Pass phrase of the private key",
+}
+
+func sshSettingsSchema() *schema.Schema {
+	block := settingsBlockSchema(sshSettings)
+	attrs := block.Elem.(*schema.Resource).Schema
+	for name, description := range sshSecrets {
+		attrs[name] = secretSchema(description, validation.ToDiagFunc(validation.StringIsNotWhiteSpace))
+	}
+	return block
+}
+
+// expandSSHSettings copies the ssh_settings block into settings, the secrets only when they changed
+func expandSSHSettings(d *schema.ResourceData, settings *client.AdvancedSettings_Complete) error {
+	if err := expandSettingsBlock(d, "ssh_settings", sshSettings, settings); err != nil {
+		return err
+	}
+	for name := range sshSecrets {
+		if value, ok := changedSecret(d, "ssh_settings.0."+name); ok {
+			if err := settings.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flattenSSHSettings returns the ssh_settings block of an application, it is empty unless app_profile is ssh.
+// The secrets are never read back from EAA, their hashes are kept from the state.
+func flattenSSHSettings(d *schema.ResourceData, app *client.ApplicationDataModel) ([]interface{}, error) {
+	if client.AppProfileInt(app.AppProfile) != client.APP_PROFILE_SSH {
+		return nil, nil
+	}
+	block, err := flattenSettingsBlock(sshSettings, &app.AdvancedSettings)
+	if err != nil {
+		return nil, err
+	}
+	data := block[0].(map[string]interface{})
+	for name := range sshSecrets {
+		data[name] = secretState(d, "ssh_settings.0."+name, hashSecret)
+	}
+	return block, nil
+}
@@ -1,13 +1,26 @@
 package eaaprovider
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+	"git.source.akamai.com/terraform-provider-eaa/pkg/eaatest"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -180,9 +193,9 @@ func testAccEaaApplicationConfig_basic(appName string, host string, appProfile s
 		domain = "wapp"
 	  
 		advanced_settings {
-			is_ssl_verification_enabled = "false"
-			ignore_cname_resolution = "true"
-			g2o_enabled = "false"
+			is_ssl_verification_enabled = false
+			ignore_cname_resolution = true
+			g2o_enabled = false
 		}
 		
 		popregion = "us-east-1"
@@ -215,9 +228,9 @@ func testAccEaaApplicationConfig_G2O(appName string, host string, appProfile str
 		domain = "wapp"
 	  
 		advanced_settings {
-			is_ssl_verification_enabled = "false"
-			ignore_cname_resolution = "true"
-			g2o_enabled = "true"
+			is_ssl_verification_enabled = false
+			ignore_cname_resolution = true
+			g2o_enabled = true
 		}
 		
 		popregion = "us-east-1"
@@ -261,9 +274,9 @@ func testAccEaaApplicationConfig_complex(appName, host, appProfile, appType, age
   }
 
   advanced_settings {
-      is_ssl_verification_enabled = "false"
-      ignore_cname_resolution = "true"
-      g2o_enabled = "false"
+      is_ssl_verification_enabled = false
+      ignore_cname_resolution = true
+      g2o_enabled = false
 			}
 
   popregion = "us-east-1"
@@ -288,3 +301,429 @@ func testAccEaaApplicationConfig_complex(appName, host, appProfile, appType, age
 func testAccPreCheck(_ *testing.T) {
 
 }
+
+// newTestAPI returns a fake EAA API seeded with the objects the unit tests reference
+func newTestAPI(t *testing.T) *eaatest.Server {
+	srv := eaatest.NewServer(t)
+	srv.AddAgent("terraform-test-connector")
+	srv.AddAgent("terraform-test-connector-2")
+	srv.AddPop("US-East", "us-east-1")
+	srv.AddAppCategory("Finance")
+	srv.AddIDP("terraform-idp", eaatest.Directory{Name: "Cloud Directory", Groups: []string{"Admins", "demo_group"}})
+	srv.AddTLSCipherSuite("TLS-Suite-v1", "TLSv1.2 TLSv1.3", false)
+	srv.AddTLSCipherSuite("TLS-Suite-Legacy", "TLSv1 TLSv1.1 TLSv1.2", true)
+	return srv
+}
+
+// testMeta returns the meta of a provider configured with the defaults, its client talks to srv
+func testMeta(srv *eaatest.Server) *providerMeta {
+	return &providerMeta{EaaClient: srv.Client(), onCreateFailure: CREATE_FAILURE_TAINT}
+}
+
+// testTerraform plans and applies configurations of one eaa_application against the fake API through the
+// SDK entry points the gRPC server of the provider calls: Validate, then SimpleDiff and Apply with the raw
+// configuration set. Unlike TestResourceDataRaw, the plan runs CustomizeDiff, the StateFuncs and the Computed handling.
+type testTerraform struct {
+	t     *testing.T
+	r     *schema.Resource
+	meta  interface{}
+	state *terraform.InstanceState
+}
+
+func newTestTerraform(t *testing.T, srv *eaatest.Server) *testTerraform {
+	return &testTerraform{t: t, r: resourceEaaApplication(), meta: testMeta(srv)}
+}
+
+// plan returns the changes config makes to the current state, an empty diff when there is none
+func (tf *testTerraform) plan(config map[string]interface{}) (*terraform.InstanceDiff, error) {
+	tf.t.Helper()
+	data, err := json.Marshal(config)
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	configVal, err := ctyjson.Unmarshal(data, tf.r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		tf.t.Fatalf("config does not match the schema: %s", err)
+	}
+	rc := terraform.NewResourceConfigShimmed(configVal, tf.r.CoreConfigSchema())
+	if diags := tf.r.Validate(rc); diags.HasError() {
+		return nil, fmt.Errorf("validate: %v", diags)
+	}
+
+	prior := tf.state
+	if prior == nil {
+		prior = &terraform.InstanceState{Attributes: map[string]string{}}
+	}
+	prior = prior.DeepCopy()
+	prior.RawConfig = configVal
+	diff, err := tf.r.SimpleDiff(context.Background(), prior, rc, tf.meta)
+	if err != nil {
+		return nil, err
+	}
+	diff.RawConfig = configVal
+	return diff, nil
+}
+
+// apply refreshes the state, plans config and applies the changes, the new state is kept for the next plan
+func (tf *testTerraform) apply(config map[string]interface{}) error {
+	tf.t.Helper()
+	if tf.state != nil {
+		tf.refresh()
+	}
+	diff, err := tf.plan(config)
+	if err != nil {
+		return err
+	}
+	if diff.Empty() {
+		return nil
+	}
+	state, diags := tf.r.Apply(context.Background(), tf.state, diff, tf.meta)
+	if state != nil && state.ID != "" {
+		tf.state = state
+	}
+	if diags.HasError() {
+		return fmt.Errorf("apply: %v", diags)
+	}
+	return nil
+}
+
+// refresh reads the application back into the state like terraform does before each plan
+func (tf *testTerraform) refresh() {
+	tf.t.Helper()
+	state, diags := tf.r.RefreshWithoutUpgrade(context.Background(), tf.state, tf.meta)
+	if diags.HasError() {
+		tf.t.Fatalf("refresh: %v", diags)
+	}
+	tf.state = state
+}
+
+// applyAndReplan applies config, then fails the test unless planning it again after a refresh shows no change
+func (tf *testTerraform) applyAndReplan(config map[string]interface{}) {
+	tf.t.Helper()
+	if err := tf.apply(config); err != nil {
+		tf.t.Fatal(err)
+	}
+	tf.refresh()
+	diff, err := tf.plan(config)
+	if err != nil {
+		tf.t.Fatal(err)
+	}
+	if !diff.Empty() {
+		for key, attr := range diff.Attributes {
+			tf.t.Errorf("re-plan changes %s: %q => %q", key, attr.Old, attr.New)
+		}
+	}
+}
+
+// testUnitProviders returns the providers of resource.UnitTest cases, the client of the provider talks to srv
+func testUnitProviders(srv *eaatest.Server) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"eaa": func() (*schema.Provider, error) {
+			p := Provider()
+			p.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				return &providerMeta{EaaClient: srv.Client(), onCreateFailure: d.Get("on_create_failure").(string)}, nil
+			}
+			return p, nil
+		},
+	}
+}
+
+// testUnitPreCheck skips resource.UnitTest cases when there is no terraform CLI to run them,
+// the plans of testTerraform cover the same paths without it
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI not found, set TF_ACC_TERRAFORM_PATH to run the plan and apply steps")
+	}
+}
+
+// testUnitAppConfig returns the HCL of eaa_application.app built like testApplicationConfig, extra is added to its body
+func testUnitAppConfig(appProfile, appType, extra string) string {
+	return fmt.Sprintf(`
+provider "eaa" {
+  contractid = "1-ABC"
+}
+
+resource "eaa_application" "app" {
+  name            = "tf-app"
+  host            = "tf-app"
+  app_profile     = %q
+  app_type        = %q
+  client_app_mode = "tcp"
+  domain          = "wapp"
+  popregion       = "us-east-1"
+  app_category    = "Finance"
+  auth_enabled    = "true"
+  agents          = ["terraform-test-connector"]
+
+  servers {
+    orig_tls        = true
+    origin_protocol = "https"
+    origin_port     = 443
+    origin_host     = "origin.example.com"
+  }
+%s
+}
+`, appProfile, appType, extra)
+}
+
+func testApplicationConfig(name string, agents ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            name,
+		"description":     "app created using terraform",
+		"host":            name,
+		"app_profile":     "http",
+		"app_type":        "enterprise",
+		"client_app_mode": "tcp",
+		"domain":          "wapp",
+		"popregion":       "us-east-1",
+		"app_category":    "Finance",
+		"auth_enabled":    "true",
+		"agents":          agents,
+		"servers": []interface{}{
+			map[string]interface{}{
+				"orig_tls":        true,
+				"origin_protocol": "https",
+				"origin_port":     443,
+				"origin_host":     "origin.example.com",
+			},
+		},
+		"advanced_settings": []interface{}{
+			map[string]interface{}{
+				"is_ssl_verification_enabled": false,
+				"ignore_cname_resolution":     true,
+				"g2o_enabled":                 true,
+			},
+		},
+		"app_authentication": []interface{}{
+			map[string]interface{}{
+				"app_idp": "terraform-idp",
+				"app_directories": []interface{}{
+					map[string]interface{}{
+						"name": "Cloud Directory",
+						"app_groups": []interface{}{
+							map[string]interface{}{"name": "Admins"},
+						},
+					},
+				},
+			},
+		},
+		"service": []interface{}{
+			map[string]interface{}{
+				"service_type": "access",
+				"status":       "on",
+				"access_rule": []interface{}{
+					map[string]interface{}{
+						"name":   "deny-admin",
+						"status": "on",
+						"rule": []interface{}{
+							map[string]interface{}{"operator": "==", "type": "url", "value": "/admin"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEaaApplicationCRUD(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig("tf-app", "terraform-test-connector"))
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	id := d.Id()
+	app, ok := srv.App(id)
+	if !ok {
+		t.Fatalf("app %q was not created", id)
+	}
+	if app.Name != "tf-app" || app.POPRegion != "us-east-1" || app.AppCategory.Name != "Finance" {
+		t.Errorf("unexpected app %+v", app.Application)
+	}
+	if srv.Deployments(id) != 1 {
+		t.Errorf("deployments = %d, want 1", srv.Deployments(id))
+	}
+	if rules := srv.AccessRules(id); len(rules) != 1 || rules[0].Name != "deny-admin" {
+		t.Errorf("access rules = %+v", rules)
+	}
+
+	checks := map[string]string{
+		"name":                         "tf-app",
+		"agents.#":                     "1",
+		"agents.0":                     "terraform-test-connector",
+		"app_deployed":                 "true",
+		"cname":                        "tf-app.go.akamai-access.com",
+		"app_authentication.0.app_idp": "terraform-idp",
+		"app_authentication.0.app_directories.0.name":              "Cloud Directory",
+		"app_authentication.0.app_directories.0.app_groups.0.name": "Admins",
+		"service.0.access_rule.0.name":                             "deny-admin",
+		"service.0.access_rule.0.rule.0.value":                     "/admin",
+		"advanced_settings.0.g2o_enabled":                          "true",
+		"advanced_settings.0.websocket_enabled":                    "true",
+		"advanced_settings.0.login_timeout":                        "5",
+		"advanced_settings.0.rate_limit":                           "true",
+	}
+	for key, want := range checks {
+		if got := fmt.Sprint(d.Get(key)); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	if d.Get("advanced_settings.0.g2o_key") == "" {
+		t.Error("g2o_key is empty")
+	}
+
+	config := testApplicationConfig("tf-app", "terraform-test-connector-2")
+	config["host"] = "tf-app-2"
+	update := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	update.SetId(id)
+	if diags := resourceEaaApplicationUpdate(ctx, update, meta); diags.HasError() {
+		t.Fatalf("update failed: %v", diags)
+	}
+	if got := update.Get("cname"); got != "tf-app-2.go.akamai-access.com" {
+		t.Errorf("cname = %v, want tf-app-2.go.akamai-access.com", got)
+	}
+	if got := fmt.Sprint(update.Get("agents")); got != "[terraform-test-connector-2]" {
+		t.Errorf("agents = %s, want [terraform-test-connector-2]", got)
+	}
+	if srv.Deployments(id) != 2 {
+		t.Errorf("deployments = %d, want 2", srv.Deployments(id))
+	}
+
+	if diags := resourceEaaApplicationDelete(ctx, update, meta); diags.HasError() {
+		t.Fatalf("delete failed: %v", diags)
+	}
+	if _, ok := srv.App(id); ok {
+		t.Error("app still exists after delete")
+	}
+	if update.Id() != "" {
+		t.Errorf("id = %q after delete", update.Id())
+	}
+}
+
+func TestEaaApplicationCreateFailure(t *testing.T) {
+	tests := map[string]struct {
+		mode       string
+		failDelete bool
+		wantID     bool
+		wantApps   int
+	}{
+		"taint":           {mode: CREATE_FAILURE_TAINT, wantID: true, wantApps: 1},
+		"rollback":        {mode: CREATE_FAILURE_ROLLBACK, wantApps: 0},
+		"rollback failed": {mode: CREATE_FAILURE_ROLLBACK, failDelete: true, wantID: true, wantApps: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := newTestAPI(t)
+			srv.FailNext(http.MethodPost, "apps/*/deploy", http.StatusBadRequest)
+			if tt.failDelete {
+				srv.FailNext(http.MethodDelete, "apps/*", http.StatusInternalServerError)
+			}
+			meta := &providerMeta{EaaClient: srv.Client(), onCreateFailure: tt.mode}
+
+			d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig("tf-app"))
+			diags := resourceEaaApplicationCreate(context.Background(), d, meta)
+			if !diags.HasError() {
+				t.Fatal("expected the deploy failure to be reported")
+			}
+			if !strings.Contains(diags[0].Summary, client.ErrDeploy.Error()) || !strings.Contains(diags[0].Detail, "problemId") {
+				t.Errorf("unexpected diagnostic %+v", diags[0])
+			}
+			if got := len(srv.AppIDs()); got != tt.wantApps {
+				t.Errorf("apps = %d, want %d", got, tt.wantApps)
+			}
+			if (d.Id() != "") != tt.wantID {
+				t.Errorf("id = %q, want it set: %t", d.Id(), tt.wantID)
+			}
+			if tt.wantID && d.Id() != srv.AppIDs()[0] {
+				t.Errorf("id = %q, want the created app %s", d.Id(), srv.AppIDs()[0])
+			}
+		})
+	}
+}
+
+func TestEaaApplicationRollbackAfterTimeout(t *testing.T) {
+	interval := deploymentPollInterval
+	deploymentPollInterval = time.Millisecond
+	t.Cleanup(func() { deploymentPollInterval = interval })
+
+	srv := newTestAPI(t)
+	srv.SlowDeployments(1000)
+	config := testApplicationConfig("tf-app")
+	config["wait_for_deployment"] = true
+	meta := &providerMeta{EaaClient: srv.Client(), onCreateFailure: CREATE_FAILURE_ROLLBACK}
+
+	// the SDK cancels the context of create when the create timeout is over
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	diags := resourceEaaApplicationCreate(ctx, d, meta)
+	if ctx.Err() == nil {
+		t.Fatal("create returned before its deadline")
+	}
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not deployed and operational in time") {
+		t.Fatalf("diags = %v, want the deployment timeout", diags)
+	}
+	if got := len(srv.AppIDs()); got != 0 {
+		t.Errorf("apps = %d, want the rollback to delete the app after the deadline", got)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q, want it removed after the rollback", d.Id())
+	}
+}
+
+func TestEaaApplicationDeletedOutsideTerraform(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+	ec := meta.EaaClient
+
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, testApplicationConfig("tf-app"))
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	id := d.Id()
+	if err := ec.SendDeleteApplicationEndpoint(ctx, id); err != nil {
+		t.Fatalf("delete from the console failed: %s", err)
+	}
+
+	if diags := resourceEaaApplicationRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q, want it removed from the state", d.Id())
+	}
+
+	d.SetId(id)
+	if diags := resourceEaaApplicationDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete of a missing app failed: %v", diags)
+	}
+
+	d.SetId(id)
+	srv.FailNext(http.MethodGet, "apps/*", http.StatusInternalServerError)
+	if diags := resourceEaaApplicationRead(ctx, d, meta); !diags.HasError() || d.Id() != id {
+		t.Errorf("diags = %v, id = %q, want other errors reported and the id kept", diags, d.Id())
+	}
+}
+
+func TestEaaApplicationTLSSuite(t *testing.T) {
+	srv := newTestAPI(t)
+
+	config := testApplicationConfig("tf-app")
+	config["tls_suite_name"] = "TLS-Suite-v1"
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(context.Background(), d, testMeta(srv)); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	app, _ := srv.App(d.Id())
+	if app.TLSSuiteName == nil || *app.TLSSuiteName != "TLS-Suite-v1" {
+		t.Errorf("sent tls_suite_name = %v, want TLS-Suite-v1", app.TLSSuiteName)
+	}
+	if got := d.Get("tls_suite_name"); got != "TLS-Suite-v1" {
+		t.Errorf("tls_suite_name = %v, want TLS-Suite-v1", got)
+	}
+}
@@ -0,0 +1,352 @@
+package eaaprovider
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceEaaApplicationV0 is eaa_application as it was at schema version 0, the states written by
+// that version are decoded with it before upgradeEaaApplicationStateV0 runs. It must not follow the current schema.
+func resourceEaaApplicationV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"app_profile": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"app_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"client_app_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bookmark_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"origin_host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"orig_tls": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"origin_port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"tunnel_internal_hosts": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"port_range": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"proto_type": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"origin_host": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"orig_tls": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"origin_port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"origin_protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"pop": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"popname": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"popregion": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"auth_enabled": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "false",
+			},
+
+			"app_operational": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"app_status": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"app_deployed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"cname": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"uuid_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"agents": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"app_category": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cert_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cert_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cert": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"generate_self_signed_cert": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"advanced_settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_ssl_verification_enabled": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "false",
+						},
+						"edge_authentication_enabled": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "false",
+						},
+						"ignore_cname_resolution": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "false",
+						},
+						"g2o_enabled": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "false",
+						},
+						"g2o_nonce": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"g2o_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"x_wapp_read_timeout": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"internal_hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"internal_host_port": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ip_access_allow": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"wildcard_internal_hostname": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"edge_cookie_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"sla_object_url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"service": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"access_rule": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"status": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"rule": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"operator": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"type": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"value": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"app_authentication": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"app_idp": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"app_directories": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"enable_mfa": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"app_groups": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"enable_mfa": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceEaaApplicationV0Type is the type of eaa_application before the advanced settings were typed,
+// all of them were strings
+func resourceEaaApplicationV0Type() cty.Type {
+	return resourceEaaApplicationV0().CoreConfigSchema().ImpliedType()
+}
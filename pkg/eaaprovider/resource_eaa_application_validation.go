@@ -0,0 +1,220 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	ErrInvalidAppConfig = errors.New("invalid application configuration")
+)
+
+// ssoBlocks are the single sign-on blocks, each of them selects the app_auth of the application
+var ssoBlocks = []string{"saml", "oidc", "wsfed"}
+
+// validateEnum accepts one of the keys of an enum map of pkg/client, e.g. client.APP_PROFILES
+func validateEnum[K ~string, V any](enum map[K]V) schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice(client.EnumValues(enum), false))
+}
+
+func validateCertType() schema.SchemaValidateDiagFunc {
+	certTypes := make([]string, 0, len(client.CERT_TYPES))
+	for _, certType := range client.CERT_TYPES {
+		certTypes = append(certTypes, string(certType))
+	}
+	return validation.ToDiagFunc(validation.StringInSlice(certTypes, false))
+}
+
+// knownString returns the planned value of key, ok is false when it is unknown until apply
+func knownString(d *schema.ResourceDiff, key string) (string, bool) {
+	if !d.NewValueKnown(key) {
+		return "", false
+	}
+	value, _ := d.Get(key).(string)
+	return value, true
+}
+
+// blockConfigured reports whether block is set in the configuration
+func blockConfigured(d *schema.ResourceDiff, block string) bool {
+	config := d.GetRawConfig()
+	if !config.IsNull() {
+		list := config.GetAttr(block)
+		return !list.IsNull() && (!list.IsKnown() || list.LengthInt() > 0)
+	}
+	// there is no raw configuration when the diff is not planned by Terraform, e.g. in unit tests
+	if !d.NewValueKnown(block) {
+		return false
+	}
+	list, _ := d.Get(block).([]interface{})
+	return len(list) > 0
+}
+
+// nestedConfigured reports whether attr of block is set in the configuration
+func nestedConfigured(d *schema.ResourceDiff, block, attr string) bool {
+	config := d.GetRawConfig()
+	if !config.IsNull() {
+		return settingConfigured(config, block, attr)
+	}
+	// there is no raw configuration when the diff is not planned by Terraform, e.g. in unit tests
+	_, ok := d.GetOk(block + ".0." + attr)
+	return ok
+}
+
+// attrConfigured reports whether the top level attribute is set in the configuration
+func attrConfigured(d *schema.ResourceDiff, attr string) bool {
+	config := d.GetRawConfig()
+	if !config.IsNull() {
+		return !config.GetAttr(attr).IsNull()
+	}
+	// there is no raw configuration when the diff is not planned by Terraform, e.g. in unit tests
+	_, ok := d.GetOk(attr)
+	return ok
+}
+
+// checkTLSSuite checks that app can select the cipher suite called name and that it is not weak unless allowWeak is set
+func checkTLSSuite(app *client.Application, name string, allowWeak bool) error {
+	suite, err := app.CipherSuite(name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAppConfig, err)
+	}
+	if suite.WeakCipher && !allowWeak {
+		return fmt.Errorf("%w: tls_suite_name %q uses weak ciphers, set allow_weak_tls_cipher to select it", ErrInvalidAppConfig, name)
+	}
+	return nil
+}
+
+// validateTLSSuite checks a changed cipher suite against the suites of the application.
+// EAA lists them per application, a new application is checked at apply before tls_suite_name is sent.
+func validateTLSSuite(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	name, ok := knownString(d, "tls_suite_name")
+	if !ok || name == "" || !attrConfigured(d, "tls_suite_name") || d.Id() == "" {
+		return nil
+	}
+	if !d.HasChange("tls_suite_name") && !d.HasChange("allow_weak_tls_cipher") {
+		return nil
+	}
+	ec, err := Client(m)
+	if err != nil {
+		return nil
+	}
+	app, err := client.GetApplication(ctx, ec, d.Id())
+	if client.IsNotFound(err) {
+		// deleted outside of terraform, the new application is checked at apply
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	allow, _ := d.Get("allow_weak_tls_cipher").(bool)
+	return checkTLSSuite(&app.Application, name, allow)
+}
+
+// validateApplicationConfig enforces the rules spanning several attributes of eaa_application.
+// Values that are only known at apply time are skipped.
+func validateApplicationConfig(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	var errs []error
+
+	if appType, ok := knownString(d, "app_type"); ok && appType == string(client.ClientAppTypeTunnel) {
+		if d.NewValueKnown("tunnel_internal_hosts") {
+			hosts, _ := d.Get("tunnel_internal_hosts").([]interface{})
+			if len(hosts) == 0 {
+				errs = append(errs, fmt.Errorf("%w: tunnel_internal_hosts is required when app_type is %q", ErrInvalidAppConfig, client.ClientAppTypeTunnel))
+			}
+		}
+	}
+
+	if domain, ok := knownString(d, "domain"); ok && domain == string(client.AppDomainCustom) {
+		if host, ok := knownString(d, "host"); ok && host == "" {
+			errs = append(errs, fmt.Errorf("%w: host is required when domain is %q", ErrInvalidAppConfig, client.AppDomainCustom))
+		}
+		if certType, ok := knownString(d, "cert_type"); ok && certType == "" {
+			errs = append(errs, fmt.Errorf("%w: cert_type is required when domain is %q", ErrInvalidAppConfig, client.AppDomainCustom))
+		}
+	}
+
+	if certType, ok := knownString(d, "cert_type"); ok && certType == string(client.CertUploaded) {
+		if certName, ok := knownString(d, "cert_name"); ok && certName == "" {
+			errs = append(errs, fmt.Errorf("%w: cert_name is required when cert_type is %q", ErrInvalidAppConfig, client.CertUploaded))
+		}
+	}
+
+	if profile, ok := knownString(d, "app_profile"); ok && profile != string(client.AppProfileRDP) && blockConfigured(d, "rdp_settings") {
+		errs = append(errs, fmt.Errorf("%w: rdp_settings is only valid when app_profile is %q", ErrInvalidAppConfig, client.AppProfileRDP))
+	}
+
+	if profile, ok := knownString(d, "app_profile"); ok && profile != string(client.AppProfileSSH) && blockConfigured(d, "ssh_settings") {
+		errs = append(errs, fmt.Errorf("%w: ssh_settings is only valid when app_profile is %q", ErrInvalidAppConfig, client.AppProfileSSH))
+	}
+	if passPhrase, ok := knownString(d, "ssh_settings.0.pass_phrase"); ok && passPhrase != "" {
+		if privateKey, ok := knownString(d, "ssh_settings.0.private_key"); ok && privateKey == "" {
+			errs = append(errs, fmt.Errorf("%w: pass_phrase requires private_key", ErrInvalidAppConfig))
+		}
+	}
+
+	// the single sign-on blocks set app_auth themselves, EAA only offers them for SaaS applications
+	for _, block := range ssoBlocks {
+		if !blockConfigured(d, block) {
+			continue
+		}
+		if appType, ok := knownString(d, "app_type"); ok && appType != string(client.ClientAppTypeSaaS) {
+			errs = append(errs, fmt.Errorf("%w: %s is only valid when app_type is %q", ErrInvalidAppConfig, block, client.ClientAppTypeSaaS))
+		}
+		if nestedConfigured(d, "kerberos", "app_auth") {
+			errs = append(errs, fmt.Errorf("%w: kerberos app_auth cannot be set with %s", ErrInvalidAppConfig, block))
+		}
+	}
+
+	if appAuth, ok := knownString(d, "kerberos.0.app_auth"); ok && appAuth == APP_AUTH_KERBEROS {
+		// the name is unknown on create when it is not configured since EAA picks the value
+		spn, ok := knownString(d, "kerberos.0.service_principal_name")
+		if (ok && spn == "") || (!ok && !settingConfigured(d.GetRawConfig(), "kerberos", "service_principal_name")) {
+			errs = append(errs, fmt.Errorf("%w: service_principal_name is required when app_auth is %q", ErrInvalidAppConfig, APP_AUTH_KERBEROS))
+		}
+	}
+
+	if checkType, ok := knownString(d, "load_balancing.0.health_check_type"); ok && (checkType == "HTTP" || checkType == "HTTPS") {
+		// the url is unknown on create when it is not configured since EAA picks the value
+		url, ok := knownString(d, "load_balancing.0.health_check_http_url")
+		if (ok && url == "") || (!ok && !settingConfigured(d.GetRawConfig(), "load_balancing", "health_check_http_url")) {
+			errs = append(errs, fmt.Errorf("%w: health_check_http_url is required when health_check_type is %q", ErrInvalidAppConfig, checkType))
+		}
+	}
+
+	for _, attr := range []string{"allowed_origins", "allowed_methods", "allowed_headers"} {
+		key := "cors.0." + attr
+		if !d.NewValueKnown(key) {
+			continue
+		}
+		items, _ := d.Get(key).([]interface{})
+		if len(items) > 1 && containsAny(items) {
+			errs = append(errs, fmt.Errorf("%w: %s cannot combine %q with other values", ErrInvalidAppConfig, attr, settingAny))
+		}
+	}
+	if d.NewValueKnown("cors.0.support_credentials") && d.NewValueKnown("cors.0.allowed_origins") {
+		origins, _ := d.Get("cors.0.allowed_origins").([]interface{})
+		if credentials, _ := d.Get("cors.0.support_credentials").(bool); credentials && containsAny(origins) {
+			errs = append(errs, fmt.Errorf("%w: support_credentials requires explicit allowed_origins, browsers reject credentials for %q", ErrInvalidAppConfig, settingAny))
+		}
+	}
+
+	if err := validateTLSSuite(ctx, d, m); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func containsAny(items []interface{}) bool {
+	for _, item := range items {
+		if item == settingAny {
+			return true
+		}
+	}
+	return false
+}
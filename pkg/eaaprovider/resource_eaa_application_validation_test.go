@@ -0,0 +1,353 @@
+package eaaprovider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// unknownValue is how the SDK marks a value that is only known at apply time in a raw config
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestApplicationEnumValidation(t *testing.T) {
+	r := resourceEaaApplication()
+	tests := map[string]string{
+		"app_profile":     "htp",
+		"app_type":        "mainframe",
+		"client_app_mode": "udp",
+		"domain":          "akamai",
+		"cert_type":       "wildcard",
+	}
+	for attr, value := range tests {
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"name": "app", attr: value}))
+		if !diags.HasError() {
+			t.Errorf("%s = %q was accepted", attr, value)
+		}
+	}
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":            "app",
+		"app_profile":     "http",
+		"app_type":        "enterprise",
+		"client_app_mode": "tcp",
+		"domain":          "wapp",
+	}))
+	if diags.HasError() {
+		t.Errorf("unexpected diags: %v", diags)
+	}
+}
+
+func TestValidateApplicationConfig(t *testing.T) {
+	tests := map[string]struct {
+		raw     map[string]interface{}
+		wantErr string
+	}{
+		"tunnel without hosts": {
+			raw:     map[string]interface{}{"name": "app", "app_type": "tunnel"},
+			wantErr: "tunnel_internal_hosts is required",
+		},
+		"tunnel with hosts": {
+			raw: map[string]interface{}{
+				"name":                  "app",
+				"app_type":              "tunnel",
+				"tunnel_internal_hosts": []interface{}{map[string]interface{}{"host": "10.0.0.1", "port_range": "22", "proto_type": 1}},
+			},
+		},
+		"custom domain without host and cert_type": {
+			raw:     map[string]interface{}{"name": "app", "domain": "custom"},
+			wantErr: "host is required",
+		},
+		"custom domain with unknown host": {
+			raw: map[string]interface{}{"name": "app", "domain": "custom", "host": unknownValue, "cert_type": "self_signed"},
+		},
+		"uploaded cert without cert_name": {
+			raw:     map[string]interface{}{"name": "app", "domain": "custom", "host": "app", "cert_type": "uploaded"},
+			wantErr: "cert_name is required",
+		},
+		"http health check without url": {
+			raw: map[string]interface{}{
+				"name":           "app",
+				"load_balancing": []interface{}{map[string]interface{}{"health_check_type": "HTTP"}},
+			},
+			wantErr: "health_check_http_url is required",
+		},
+		"http health check": {
+			raw: map[string]interface{}{
+				"name":           "app",
+				"load_balancing": []interface{}{map[string]interface{}{"health_check_type": "HTTP", "health_check_http_url": "/healthz"}},
+			},
+		},
+		"tcp health check": {
+			raw: map[string]interface{}{
+				"name":           "app",
+				"load_balancing": []interface{}{map[string]interface{}{"health_check_type": "TCP"}},
+			},
+		},
+		"cors wildcard mixed with origins": {
+			raw: map[string]interface{}{
+				"name": "app",
+				"cors": []interface{}{map[string]interface{}{"allowed_origins": []interface{}{"*", "https://a.example.com"}}},
+			},
+			wantErr: "allowed_origins cannot combine",
+		},
+		"cors credentials with any origin": {
+			raw: map[string]interface{}{
+				"name": "app",
+				"cors": []interface{}{map[string]interface{}{"allowed_origins": []interface{}{"*"}, "support_credentials": true}},
+			},
+			wantErr: "support_credentials requires explicit allowed_origins",
+		},
+		"cors credentials": {
+			raw: map[string]interface{}{
+				"name": "app",
+				"cors": []interface{}{map[string]interface{}{"allowed_origins": []interface{}{"https://a.example.com"}, "support_credentials": true}},
+			},
+		},
+		"rdp settings on http app": {
+			raw: map[string]interface{}{
+				"name":         "app",
+				"app_profile":  "http",
+				"rdp_settings": []interface{}{map[string]interface{}{"clipboard": true}},
+			},
+			wantErr: "rdp_settings is only valid when app_profile is \"rdp\"",
+		},
+		"rdp settings": {
+			raw: map[string]interface{}{
+				"name":         "app",
+				"app_profile":  "rdp",
+				"rdp_settings": []interface{}{map[string]interface{}{"clipboard": true}},
+			},
+		},
+		"ssh settings on rdp app": {
+			raw: map[string]interface{}{
+				"name":         "app",
+				"app_profile":  "rdp",
+				"ssh_settings": []interface{}{map[string]interface{}{"user_name": "deploy"}},
+			},
+			wantErr: "ssh_settings is only valid when app_profile is \"ssh\"",
+		},
+		"ssh pass phrase without key": {
+			raw: map[string]interface{}{
+				"name":         "app",
+				"app_profile":  "ssh",
+				"ssh_settings": []interface{}{map[string]interface{}{"pass_phrase": "secret"}},
+			},
+			wantErr: "pass_phrase requires private_key",
+		},
+		"kerberos without spn": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"kerberos": []interface{}{map[string]interface{}{"app_auth": "kerberos"}},
+			},
+			wantErr: "service_principal_name is required",
+		},
+		"ntlm without spn": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"kerberos": []interface{}{map[string]interface{}{"app_auth": "NTLMv2"}},
+			},
+		},
+		"saml on enterprise app": {
+			raw: map[string]interface{}{
+				"name": "app",
+				"saml": []interface{}{map[string]interface{}{"sp": []interface{}{map[string]interface{}{"entity_id": "sp", "acs_url": "https://sp.example.com/acs"}}}},
+			},
+			wantErr: "saml is only valid when app_type is \"saas\"",
+		},
+		"saml": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"saml":     []interface{}{map[string]interface{}{"sp": []interface{}{map[string]interface{}{"entity_id": "sp", "acs_url": "https://sp.example.com/acs"}}}},
+			},
+		},
+		"saml with kerberos app_auth": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"saml":     []interface{}{map[string]interface{}{"sp": []interface{}{map[string]interface{}{"entity_id": "sp", "acs_url": "https://sp.example.com/acs"}}}},
+				"kerberos": []interface{}{map[string]interface{}{"app_auth": "NTLMv2"}},
+			},
+			wantErr: "kerberos app_auth cannot be set with saml",
+		},
+		"saml with kerberos settings": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"saml":     []interface{}{map[string]interface{}{"sp": []interface{}{map[string]interface{}{"entity_id": "sp", "acs_url": "https://sp.example.com/acs"}}}},
+				"kerberos": []interface{}{map[string]interface{}{"negotiate_once": true}},
+			},
+		},
+		"oidc on enterprise app": {
+			raw: map[string]interface{}{
+				"name": "app",
+				"oidc": []interface{}{map[string]interface{}{"redirect_uris": []interface{}{"https://rp.example.com/callback"}}},
+			},
+			wantErr: "oidc is only valid when app_type is \"saas\"",
+		},
+		"oidc with kerberos app_auth": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"oidc":     []interface{}{map[string]interface{}{"redirect_uris": []interface{}{"https://rp.example.com/callback"}}},
+				"kerberos": []interface{}{map[string]interface{}{"app_auth": "none"}},
+			},
+			wantErr: "kerberos app_auth cannot be set with oidc",
+		},
+		"oidc": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"oidc":     []interface{}{map[string]interface{}{"redirect_uris": []interface{}{"https://rp.example.com/callback"}}},
+			},
+		},
+		"wsfed on enterprise app": {
+			raw: map[string]interface{}{
+				"name":  "app",
+				"wsfed": []interface{}{map[string]interface{}{"realm": "urn:app:payroll", "reply_url": "https://payroll.example.com/"}},
+			},
+			wantErr: "wsfed is only valid when app_type is \"saas\"",
+		},
+		"wsfed with kerberos app_auth": {
+			raw: map[string]interface{}{
+				"name":     "app",
+				"app_type": "saas",
+				"wsfed":    []interface{}{map[string]interface{}{"realm": "urn:app:payroll", "reply_url": "https://payroll.example.com/"}},
+				"kerberos": []interface{}{map[string]interface{}{"app_auth": "kerberos", "service_principal_name": "HTTP/app.example.com"}},
+			},
+			wantErr: "kerberos app_auth cannot be set with wsfed",
+		},
+		"uploaded cert": {
+			raw: map[string]interface{}{"name": "app", "domain": "custom", "host": "app", "cert_type": "uploaded", "cert_name": "app.example.com"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := resourceEaaApplication().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+			checkConfigErr(t, err, tt.wantErr)
+		})
+		if containsUnknown(tt.raw) {
+			continue
+		}
+		// the same rules through a plan, which sets the raw configuration and the Computed values like terraform does
+		t.Run(name+" plan", func(t *testing.T) {
+			_, err := newTestTerraform(t, newTestAPI(t)).plan(tt.raw)
+			checkConfigErr(t, err, tt.wantErr)
+		})
+	}
+}
+
+func checkConfigErr(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		return
+	}
+	if !errors.Is(err, ErrInvalidAppConfig) || !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("err = %v, want %q", err, wantErr)
+	}
+}
+
+// containsUnknown reports whether raw holds unknownValue, which a plan of testTerraform cannot express
+func containsUnknown(raw map[string]interface{}) bool {
+	for _, value := range raw {
+		if value == unknownValue {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateTLSSuite(t *testing.T) {
+	tests := map[string]struct {
+		suite      string
+		allowWeak  bool
+		wantErr    string
+		wantCreate string
+	}{
+		"strong suite": {suite: "TLS-Suite-v1"},
+		"weak suite": {
+			suite:      "TLS-Suite-Legacy",
+			wantErr:    "uses weak ciphers",
+			wantCreate: "uses weak ciphers",
+		},
+		"weak suite allowed": {suite: "TLS-Suite-Legacy", allowWeak: true},
+		"unknown suite": {
+			suite:      "TLS-Suite-v9",
+			wantErr:    "tls cipher suite does not exist",
+			wantCreate: "tls cipher suite does not exist",
+		},
+		"suite picked by EAA": {},
+	}
+	for name, tt := range tests {
+		config := testApplicationConfig("tf-app")
+		if tt.suite != "" {
+			config["tls_suite_name"] = tt.suite
+		}
+		config["allow_weak_tls_cipher"] = tt.allowWeak
+
+		// the plan of an existing application checks the suites of the application
+		t.Run(name, func(t *testing.T) {
+			tf := newTestTerraform(t, newTestAPI(t))
+			if err := tf.apply(testApplicationConfig("tf-app")); err != nil {
+				t.Fatal(err)
+			}
+			_, err := tf.plan(config)
+			checkConfigErr(t, err, tt.wantErr)
+		})
+		// a new application has no suites before it is created, they are checked at apply
+		t.Run(name+" create", func(t *testing.T) {
+			tf := newTestTerraform(t, newTestAPI(t))
+			if _, err := tf.plan(config); err != nil {
+				t.Fatalf("unexpected plan error: %s", err)
+			}
+			err := tf.apply(config)
+			if tt.wantCreate == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantCreate) {
+				t.Errorf("err = %v, want %q", err, tt.wantCreate)
+			}
+		})
+	}
+}
+
+func TestValidateTLSSuiteUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  tls_suite_name = "TLS-Suite-v1"
+`),
+				Check: resource.TestCheckResourceAttr("eaa_application.app", "tls_suite_name", "TLS-Suite-v1"),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  tls_suite_name = "TLS-Suite-Legacy"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("uses weak ciphers"),
+			},
+			{
+				Config: testUnitAppConfig("http", "enterprise", `
+  tls_suite_name        = "TLS-Suite-Legacy"
+  allow_weak_tls_cipher = true
+`),
+				Check: resource.TestCheckResourceAttr("eaa_application.app", "tls_suite_name", "TLS-Suite-Legacy"),
+			},
+		},
+	})
+}
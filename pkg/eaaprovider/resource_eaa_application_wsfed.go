@@ -0,0 +1,151 @@
+package eaaprovider
+
+import (
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const APP_AUTH_WSFED = "WS-Federation"
+
+func wsfedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"saml", "oidc"},
+		Description:   "WS-Federation single sign-on, EAA acts as the identity provider of the relying party",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"realm": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Realm of the relying party, e.g. urn:app:example",
+				},
+				"reply_url": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+					Description:      "URL the tokens are posted to",
+				},
+				"logout_url": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+					Description:      "URL the user is redirected to after logging out",
+				},
+				"token_lifetime": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          3600,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(60)),
+					Description:      "Lifetime of the tokens in seconds",
+				},
+				"sign_algo": samlString("Signature algorithm of the tokens: SHA1 or SHA256", "SHA256", samlSignAlgos...),
+				"subject":   subjectSchema(),
+				"claim": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Claims added to the tokens",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Claim type, e.g. http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn",
+							},
+							"src":  samlString("User attribute the value is taken from, e.g. user.userPrincipleName", ""),
+							"val":  samlString("Fixed value, used instead of src", ""),
+							"rule": samlString("Expression the value is computed with", ""),
+						},
+					},
+				},
+				"idp_entity_id":           samlComputed("Entity ID of the IdP"),
+				"idp_sign_cert":           samlComputed("PEM certificate the tokens are signed with"),
+				"federation_metadata_url": samlComputed("URL of the federation metadata of the IdP"),
+			},
+		},
+	}
+}
+
+// expandWSFed copies the wsfed block into appUpdateReq. The IdP values generated by EAA are kept from the application.
+func expandWSFed(d *schema.ResourceData, appUpdateReq *client.ApplicationUpdateRequest) error {
+	wsfed := firstBlock(d.Get("wsfed"))
+	if wsfed == nil {
+		if d.HasChange("wsfed") {
+			appUpdateReq.WSFED = false
+			resetAppAuth(appUpdateReq, APP_AUTH_WSFED)
+		}
+		return nil
+	}
+
+	config := client.WSFedConfig{
+		SP:      client.WSFedSPProperties{RespBind: "post", EncrAlgo: "aes256-cbc"},
+		IDP:     client.WSFedIDPProperties{SelfSigned: true},
+		Subject: client.SubjectProperties{Fmt: "email", Src: "user.email"},
+	}
+	if len(appUpdateReq.WSFedSettings) > 0 {
+		config = appUpdateReq.WSFedSettings[0]
+	}
+
+	config.SP.EntityID = wsfed["realm"].(string)
+	config.SP.DSTURL = wsfed["reply_url"].(string)
+	config.SP.SLOURL = wsfed["logout_url"].(string)
+	config.SP.TokenLife = wsfed["token_lifetime"].(int)
+	config.IDP.SignAlgo = wsfed["sign_algo"].(string)
+	if subject := firstBlock(wsfed["subject"]); subject != nil {
+		config.Subject = expandSubject(subject)
+	}
+
+	config.Attrmap = []client.AttrMapping{}
+	claims, _ := wsfed["claim"].([]interface{})
+	for _, c := range claims {
+		claim, ok := c.(map[string]interface{})
+		if !ok {
+			return ErrInvalidData
+		}
+		config.Attrmap = append(config.Attrmap, client.AttrMapping{
+			Name: claim["name"].(string),
+			Fmt:  "uri",
+			Src:  claim["src"].(string),
+			Val:  claim["val"].(string),
+			Rule: claim["rule"].(string),
+		})
+	}
+
+	appUpdateReq.WSFED = true
+	appUpdateReq.WSFedSettings = []client.WSFedConfig{config}
+	appUpdateReq.AdvancedSettings.AppAuth = APP_AUTH_WSFED
+	return nil
+}
+
+// flattenWSFed returns the wsfed block of an application, it is empty unless WS-Federation is enabled
+func flattenWSFed(app *client.ApplicationDataModel) []interface{} {
+	if !app.WSFED || len(app.WSFedSettings) == 0 {
+		return nil
+	}
+	config := app.WSFedSettings[0]
+
+	claims := make([]interface{}, len(config.Attrmap))
+	for i, attr := range config.Attrmap {
+		claims[i] = map[string]interface{}{
+			"name": attr.Name,
+			"src":  attr.Src,
+			"val":  attr.Val,
+			"rule": attr.Rule,
+		}
+	}
+	return []interface{}{map[string]interface{}{
+		"realm":                   config.SP.EntityID,
+		"reply_url":               config.SP.DSTURL,
+		"logout_url":              config.SP.SLOURL,
+		"token_lifetime":          config.SP.TokenLife,
+		"sign_algo":               config.IDP.SignAlgo,
+		"subject":                 flattenSubject(config.Subject),
+		"claim":                   claims,
+		"idp_entity_id":           config.IDP.EntityID,
+		"idp_sign_cert":           config.IDP.SignCert,
+		"federation_metadata_url": config.IDP.MetadataURL,
+	}}
+}
@@ -0,0 +1,101 @@
+package eaaprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEaaApplicationWSFed(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestAPI(t)
+	meta := testMeta(srv)
+
+	config := testApplicationConfig("tf-wsfed-app", "terraform-test-connector")
+	config["app_type"] = "saas"
+	config["wsfed"] = []interface{}{
+		map[string]interface{}{
+			"realm":          "urn:app:payroll",
+			"reply_url":      "https://payroll.example.com/",
+			"token_lifetime": 600,
+			"subject":        []interface{}{map[string]interface{}{"fmt": "unspecified", "src": "user.userPrincipleName"}},
+			"claim": []interface{}{
+				map[string]interface{}{"name": "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn", "src": "user.userPrincipleName"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceEaaApplication().Schema, config)
+	if diags := resourceEaaApplicationCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+
+	app, _ := srv.App(d.Id())
+	if !app.WSFED || len(app.WSFedSettings) != 1 {
+		t.Fatalf("wsfed = %t, wsfed_settings = %v", app.WSFED, app.WSFedSettings)
+	}
+	if app.AdvancedSettings.AppAuth != APP_AUTH_WSFED {
+		t.Errorf("app_auth = %q, want %q", app.AdvancedSettings.AppAuth, APP_AUTH_WSFED)
+	}
+	sent := app.WSFedSettings[0]
+	if sent.SP.EntityID != "urn:app:payroll" || sent.SP.DSTURL != "https://payroll.example.com/" || sent.SP.TokenLife != 600 {
+		t.Errorf("sent sp = %+v", sent.SP)
+	}
+	if sent.Subject.Fmt != "unspecified" || len(sent.Attrmap) != 1 {
+		t.Errorf("sent subject = %+v, attrmap = %+v", sent.Subject, sent.Attrmap)
+	}
+
+	// the settings come back on import
+	imported := resourceEaaApplication().TestResourceData()
+	imported.SetId(d.Id())
+	if diags := resourceEaaApplicationRead(ctx, imported, meta); diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+	for _, key := range []string{
+		"wsfed.0.realm",
+		"wsfed.0.reply_url",
+		"wsfed.0.token_lifetime",
+		"wsfed.0.sign_algo",
+		"wsfed.0.subject.0.src",
+		"wsfed.0.claim.0.name",
+	} {
+		if got, want := fmt.Sprint(imported.Get(key)), fmt.Sprint(d.Get(key)); got != want {
+			t.Errorf("imported %s = %q, want %q", key, got, want)
+		}
+	}
+	if got := imported.Get("wsfed.0.federation_metadata_url"); got != sent.IDP.MetadataURL || got == "" {
+		t.Errorf("federation_metadata_url = %q, want %q", got, sent.IDP.MetadataURL)
+	}
+}
+
+func TestEaaApplicationWSFedUnit(t *testing.T) {
+	srv := newTestAPI(t)
+	wsfed := `
+  wsfed {
+    realm     = "urn:app:payroll"
+    reply_url = "https://payroll.example.com/"
+  }
+`
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviders(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitAppConfig("http", "saas", wsfed),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_WSFED),
+			},
+			{
+				Config: testUnitAppConfig("http", "saas", ""),
+				Check:  resource.TestCheckResourceAttr("eaa_application.app", "kerberos.0.app_auth", APP_AUTH_NONE),
+			},
+			{
+				Config:      testUnitAppConfig("http", "enterprise", wsfed),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`wsfed is only valid when app_type is "saas"`),
+			},
+		},
+	})
+}
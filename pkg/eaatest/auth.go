@@ -0,0 +1,67 @@
+package eaatest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const AUTH_TYPE = "EG1-HMAC-SHA256"
+
+var ErrUnauthorized = errors.New("invalid EdgeGrid signature")
+
+// verifySignature checks the EG1-HMAC-SHA256 Authorization header the same way EdgeGrid does.
+// body is the request payload already read from r.
+func (s *Server) verifySignature(r *http.Request, body []byte) error {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, AUTH_TYPE+" ") {
+		return fmt.Errorf("%w: missing %s authorization", ErrUnauthorized, AUTH_TYPE)
+	}
+
+	fields := make(map[string]string)
+	for _, part := range strings.Split(strings.TrimPrefix(header, AUTH_TYPE+" "), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if ok {
+			fields[key] = value
+		}
+	}
+	if fields["client_token"] != s.Config.ClientToken || fields["access_token"] != s.Config.AccessToken {
+		return fmt.Errorf("%w: unknown tokens", ErrUnauthorized)
+	}
+	if fields["timestamp"] == "" || fields["nonce"] == "" || fields["signature"] == "" {
+		return fmt.Errorf("%w: incomplete authorization", ErrUnauthorized)
+	}
+
+	msgPath := r.URL.EscapedPath()
+	if r.URL.RawQuery != "" {
+		msgPath = fmt.Sprintf("%s?%s", msgPath, r.URL.RawQuery)
+	}
+	contentHash := ""
+	if r.Method == http.MethodPost && len(body) > 0 {
+		signed := body
+		if len(signed) > s.Config.MaxBody {
+			signed = signed[:s.Config.MaxBody]
+		}
+		sum := sha256.Sum256(signed)
+		contentHash = base64.StdEncoding.EncodeToString(sum[:])
+	}
+	unsigned := fmt.Sprintf("%s client_token=%s;access_token=%s;timestamp=%s;nonce=%s;",
+		AUTH_TYPE, fields["client_token"], fields["access_token"], fields["timestamp"], fields["nonce"])
+	msg := strings.Join([]string{r.Method, "https", r.Host, msgPath, "", contentHash, unsigned}, "\t")
+
+	key := sign(fields["timestamp"], s.Config.ClientSecret)
+	if !hmac.Equal([]byte(sign(msg, key)), []byte(fields["signature"])) {
+		return fmt.Errorf("%w: signature mismatch", ErrUnauthorized)
+	}
+	return nil
+}
+
+func sign(message, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,781 @@
+package eaatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+)
+
+// assignments is the payload of the appdirectories and appgroups endpoints
+type assignments struct {
+	Data []struct {
+		Apps        []string `json:"apps"`
+		Directories []struct {
+			UUIDURL string `json:"uuid_url"`
+		} `json:"directories"`
+		Groups []struct {
+			UUIDURL   string  `json:"uuid_url"`
+			EnableMFA *string `json:"enable_mfa"`
+		} `json:"groups"`
+	} `json:"data"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.writeProblem(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.verifySignature(r, body); err != nil {
+		s.writeProblem(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	prefix := "/" + client.MGMT_POP_URL + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		s.writeProblem(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+		return
+	}
+	resource := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	// deletions of assignments are POSTs flagged with ?method=delete
+	method := r.Method
+	if method == http.MethodPost && strings.EqualFold(r.URL.Query().Get("method"), http.MethodDelete) {
+		method = http.MethodDelete
+	}
+
+	if status, ok := s.takeFailure(r.Method, resource); ok {
+		s.writeProblem(w, status, "injected failure")
+		return
+	}
+	s.route(w, r, method, strings.Split(resource, "/"), body)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, method string, seg []string, body []byte) {
+	switch {
+	case len(seg) == 1 && seg[0] == "apps" && method == http.MethodGet:
+		s.listApps(w, r)
+	case len(seg) == 1 && seg[0] == "apps" && method == http.MethodPost:
+		s.createApp(w, body)
+	case len(seg) == 2 && seg[0] == "apps":
+		s.routeApp(w, method, seg[1], body)
+	case len(seg) == 3 && seg[0] == "apps":
+		s.routeAppChild(w, r, method, seg[1], seg[2], body)
+
+	case len(seg) == 1 && seg[0] == "appidp" && method == http.MethodPost:
+		s.assignIDP(w, body)
+	case len(seg) == 1 && seg[0] == "appidp" && method == http.MethodDelete:
+		s.unassignIDP(w, body)
+	case len(seg) == 1 && seg[0] == "appdirectories" && method == http.MethodPost:
+		s.assignDirectories(w, body)
+	case len(seg) == 1 && seg[0] == "appgroups" && method == http.MethodPost:
+		s.assignGroups(w, body)
+
+	case len(seg) == 2 && seg[0] == "services" && method == http.MethodPut:
+		s.updateService(w, seg[1], body)
+	case len(seg) == 3 && seg[0] == "services" && seg[2] == "rules":
+		s.routeRules(w, r, method, seg[1], body)
+	case len(seg) == 4 && seg[0] == "services" && seg[2] == "rules":
+		s.routeRule(w, method, seg[1], seg[3], body)
+
+	case len(seg) == 1 && seg[0] == "agents" && method == http.MethodGet:
+		writeList(w, r, s.agents)
+	case len(seg) == 1 && seg[0] == "pops" && method == http.MethodGet:
+		writeList(w, r, s.pops)
+	case len(seg) == 1 && seg[0] == "appcategories" && method == http.MethodGet:
+		writeList(w, r, s.cats)
+	case len(seg) == 1 && seg[0] == "idp" && method == http.MethodGet:
+		idps := make([]client.IDPResponseData, 0, len(s.idps))
+		for _, i := range s.idps {
+			idps = append(idps, i.data)
+		}
+		writeList(w, r, idps)
+	case len(seg) == 3 && seg[0] == "idp" && seg[2] == "directories" && method == http.MethodGet:
+		i := s.findIDP(seg[1])
+		if i == nil {
+			s.writeProblem(w, http.StatusNotFound, "idp not found")
+			return
+		}
+		writeList(w, r, i.directories)
+
+	case len(seg) == 1 && seg[0] == "certificates" && method == http.MethodPost:
+		s.createCertificate(w, body)
+	case len(seg) == 2 && seg[0] == "certificates" && seg[1] == "thin" && method == http.MethodGet:
+		certs := make([]client.CertObject, 0, len(s.certs))
+		for _, cert := range s.certs {
+			certs = append(certs, client.CertObject{Name: cert.Name, UUIDURL: cert.UUIDURL, CertType: cert.CertType})
+		}
+		writeList(w, r, certs)
+	case len(seg) == 2 && seg[0] == "certificates" && method == http.MethodGet:
+		for _, cert := range s.certs {
+			if cert.UUIDURL == seg[1] {
+				writeJSON(w, http.StatusOK, cert)
+				return
+			}
+		}
+		s.writeProblem(w, http.StatusNotFound, "certificate not found")
+
+	default:
+		s.writeProblem(w, http.StatusNotFound, fmt.Sprintf("unknown endpoint %s %s", method, strings.Join(seg, "/")))
+	}
+}
+
+func (s *Server) listApps(w http.ResponseWriter, r *http.Request) {
+	apps := make([]map[string]interface{}, 0, len(s.appOrder))
+	for _, id := range s.appOrder {
+		apps = append(apps, s.apps[id].doc)
+	}
+	writeList(w, r, apps)
+}
+
+func (s *Server) createApp(w http.ResponseWriter, body []byte) {
+	var req client.CreateAppRequest
+	if !s.decode(w, body, &req) {
+		return
+	}
+	if req.Name == "" {
+		s.writeProblem(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	id := s.newID("app")
+	serviceID := s.newID("svc")
+	s.services[serviceID] = &service{
+		appID: id,
+		svc: client.AppService{
+			Name:        "Access Control",
+			ServiceType: client.SERVICE_TYPE_ACCESS_CTRL,
+			Status:      "off",
+			UUIDURL:     serviceID,
+		},
+	}
+	a := &app{
+		serviceID: serviceID,
+		doc: map[string]interface{}{
+			"uuid_url":        id,
+			"name":            req.Name,
+			"description":     req.Description,
+			"app_profile":     req.AppProfile,
+			"app_type":        req.AppType,
+			"client_app_mode": req.ClientAppMode,
+			"host":            nil,
+			"bookmark_url":    "",
+			"domain":          int(client.APP_DOMAIN_WAPP),
+			"auth_enabled":    "false",
+			"advanced_settings": map[string]interface{}{
+				"sso":               client.STR_TRUE,
+				"websocket_enabled": client.STR_TRUE,
+				"login_timeout":     "5",
+				"rate_limit":        client.STR_ON,
+			},
+			"servers":               []interface{}{},
+			"tunnel_internal_hosts": []interface{}{},
+			"app_deployed":          false,
+			"app_operational":       0,
+			"app_status":            0,
+			"cert":                  nil,
+			"cname":                 nil,
+			"origin_host":           nil,
+			"orig_tls":              "",
+			"origin_port":           0,
+			"pop":                   "",
+			"popName":               "",
+			"popRegion":             "",
+			"tls_suite_name":        nil,
+		},
+	}
+	a.doc["tls_cipher_suite"] = s.cipherSuites("")
+	s.apps[id] = a
+	s.appOrder = append(s.appOrder, id)
+	writeJSON(w, http.StatusOK, a.doc)
+}
+
+func (s *Server) routeApp(w http.ResponseWriter, method, id string, body []byte) {
+	a, ok := s.apps[id]
+	if !ok {
+		s.writeProblem(w, http.StatusNotFound, "app not found")
+		return
+	}
+	switch method {
+	case http.MethodGet:
+		if a.pending > 0 {
+			a.pending--
+			if a.pending == 0 {
+				s.finishDeployment(a)
+			}
+		}
+		writeJSON(w, http.StatusOK, a.doc)
+	case http.MethodPut:
+		var update map[string]interface{}
+		if !s.decode(w, body, &update) {
+			return
+		}
+		if name, _ := update["tls_suite_name"].(string); name != "" {
+			if _, ok := s.cipherSuites(name)[name]; !ok {
+				s.writeProblem(w, http.StatusBadRequest, "unknown tls_suite_name")
+				return
+			}
+		}
+		for key, value := range update {
+			if key == "uuid_url" {
+				continue
+			}
+			// the update payload carries the domain as a string
+			if domain, ok := value.(string); ok && key == "domain" {
+				n, err := strconv.Atoi(domain)
+				if err != nil {
+					s.writeProblem(w, http.StatusBadRequest, "invalid domain")
+					return
+				}
+				value = n
+			}
+			a.doc[key] = value
+		}
+		if host, ok := a.doc["host"].(string); ok && host != "" {
+			a.doc["cname"] = host + ".go.akamai-access.com"
+		}
+		suiteName, _ := a.doc["tls_suite_name"].(string)
+		a.doc["tls_cipher_suite"] = s.cipherSuites(suiteName)
+		generateIDP(id, a.doc)
+		generateOIDC(id, a.doc)
+		generateWSFed(id, a.doc)
+		a.doc["app_deployed"] = false
+		writeJSON(w, http.StatusOK, a.doc)
+	case http.MethodDelete:
+		delete(s.services, a.serviceID)
+		delete(s.apps, id)
+		for i, appID := range s.appOrder {
+			if appID == id {
+				s.appOrder = append(s.appOrder[:i], s.appOrder[i+1:]...)
+				break
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		s.writeProblem(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) routeAppChild(w http.ResponseWriter, r *http.Request, method, id, child string, body []byte) {
+	a, ok := s.apps[id]
+	if !ok {
+		s.writeProblem(w, http.StatusNotFound, "app not found")
+		return
+	}
+	switch {
+	case child == "deploy" && method == http.MethodPost:
+		var deploy struct {
+			DeployNote string `json:"deploy_note"`
+		}
+		if !s.decode(w, body, &deploy) {
+			return
+		}
+		a.deployments++
+		a.deployNote = deploy.DeployNote
+		a.pending = s.deployPolls
+		if a.pending > 0 {
+			a.doc["app_deployed"] = false
+			a.doc["app_status"] = int(client.APP_STATUS_PENDING)
+			a.doc["app_operational"] = 0
+		} else {
+			s.finishDeployment(a)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case child == "g2o" && method == http.MethodPost:
+		writeJSON(w, http.StatusOK, client.G2O_Response{
+			G2OEnabled: client.STR_TRUE,
+			G2ONonce:   s.newID("nonce"),
+			G2OKey:     s.newID("g2okey"),
+		})
+	case child == "edgekey" && method == http.MethodPost:
+		writeJSON(w, http.StatusOK, client.EdgeAuth_Response{
+			EdgeCookieKey: s.newID("cookiekey"),
+			SlaObjectUrl:  "/" + s.newID("sla") + ".html",
+		})
+	case child == "agents":
+		s.routeAppAgents(w, r, method, a, body)
+	case child == "idp_membership" && method == http.MethodGet:
+		memberships := []client.AppIdpMembership{}
+		if a.idp != nil {
+			memberships = append(memberships, *a.idp)
+		}
+		writeList(w, r, memberships)
+	case child == "directories_membership" && method == http.MethodGet:
+		writeList(w, r, a.directories)
+	case child == "groups" && method == http.MethodGet:
+		writeList(w, r, a.groups)
+	case child == "services" && method == http.MethodGet:
+		svc := s.services[a.serviceID]
+		writeList(w, r, []client.AppServiceData{{Service: svc.svc, Status: 1, UUIDURL: svc.svc.UUIDURL}})
+	default:
+		s.writeProblem(w, http.StatusNotFound, "unknown endpoint apps/"+id+"/"+child)
+	}
+}
+
+func (s *Server) routeAppAgents(w http.ResponseWriter, r *http.Request, method string, a *app, body []byte) {
+	switch method {
+	case http.MethodGet:
+		type appAgent struct {
+			Agent client.Connector `json:"agent"`
+		}
+		agents := []appAgent{}
+		for _, id := range a.agents {
+			if agent := s.findAgent(id); agent != nil {
+				agents = append(agents, appAgent{Agent: client.Connector{Name: agent.Name, UUIDURL: agent.UUIDURL}})
+			}
+		}
+		writeList(w, r, agents)
+	case http.MethodPost:
+		var req client.AssignAgentsRequest
+		if !s.decode(w, body, &req) {
+			return
+		}
+		for _, agent := range req.Agents {
+			if s.findAgent(agent.UUIDURL) == nil {
+				s.writeProblem(w, http.StatusBadRequest, "Action failed - Unable to process request")
+				return
+			}
+		}
+		for _, agent := range req.Agents {
+			if !contains(a.agents, agent.UUIDURL) {
+				a.agents = append(a.agents, agent.UUIDURL)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case http.MethodDelete:
+		var req client.UnAssignAgentsRequest
+		if !s.decode(w, body, &req) {
+			return
+		}
+		kept := a.agents[:0]
+		for _, id := range a.agents {
+			if !contains(req.Agents, id) {
+				kept = append(kept, id)
+			}
+		}
+		a.agents = kept
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		s.writeProblem(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) assignIDP(w http.ResponseWriter, body []byte) {
+	var req client.AppIdp
+	if !s.decode(w, body, &req) {
+		return
+	}
+	a, ok := s.apps[req.App]
+	i := s.findIDP(req.IDP)
+	if !ok || i == nil {
+		s.writeProblem(w, http.StatusBadRequest, "unknown app or idp")
+		return
+	}
+	a.idp = &client.AppIdpMembership{
+		App:       client.AppMembership{AppUUIDURL: req.App, Name: a.doc["name"].(string)},
+		IDP:       client.IDPMembership{IDPUUIDURL: i.data.UUIDURL, Name: i.data.Name},
+		EnableMFA: "inherit",
+		UUIDURL:   s.newID("appidp"),
+	}
+	a.directories = nil
+	a.groups = nil
+	writeJSON(w, http.StatusOK, a.idp)
+}
+
+func (s *Server) unassignIDP(w http.ResponseWriter, body []byte) {
+	var req client.UnAssignIDPRequest
+	if !s.decode(w, body, &req) {
+		return
+	}
+	for _, a := range s.apps {
+		if a.idp != nil && (contains(req.IDP, a.idp.UUIDURL) || contains(req.IDP, a.idp.IDP.IDPUUIDURL)) {
+			a.idp = nil
+			a.directories = nil
+			a.groups = nil
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) assignDirectories(w http.ResponseWriter, body []byte) {
+	var req assignments
+	if !s.decode(w, body, &req) {
+		return
+	}
+	for _, data := range req.Data {
+		for _, appID := range data.Apps {
+			a, ok := s.apps[appID]
+			if !ok || a.idp == nil {
+				s.writeProblem(w, http.StatusBadRequest, "app has no idp assigned")
+				return
+			}
+			for _, dir := range data.Directories {
+				directory := s.findDirectory(a.idp.IDP.IDPUUIDURL, dir.UUIDURL)
+				if directory == nil {
+					s.writeProblem(w, http.StatusBadRequest, "directory does not belong to the app idp")
+					return
+				}
+				if hasDirectory(a.directories, dir.UUIDURL) {
+					continue
+				}
+				a.directories = append(a.directories, client.AppDirectoryMembership{
+					App:       a.idp.App,
+					Directory: client.DirectoryMembership{DirectoryUUIDURL: directory.UUID, Name: directory.Name},
+					EnableMFA: "inherit",
+					UUIDURL:   s.newID("appdir"),
+				})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) assignGroups(w http.ResponseWriter, body []byte) {
+	var req assignments
+	if !s.decode(w, body, &req) {
+		return
+	}
+	for _, data := range req.Data {
+		for _, appID := range data.Apps {
+			a, ok := s.apps[appID]
+			if !ok || a.idp == nil {
+				s.writeProblem(w, http.StatusBadRequest, "app has no idp assigned")
+				return
+			}
+			for _, grp := range data.Groups {
+				directory, group := s.findGroup(a.idp.IDP.IDPUUIDURL, grp.UUIDURL)
+				if group == nil {
+					s.writeProblem(w, http.StatusBadRequest, "group does not belong to the app idp")
+					return
+				}
+				enableMFA := "inherit"
+				if grp.EnableMFA != nil && *grp.EnableMFA != "" {
+					enableMFA = *grp.EnableMFA
+				}
+				a.groups = append(a.groups, client.AppGroupMembership{
+					App:       a.idp.App,
+					EnableMFA: enableMFA,
+					Group: client.GroupMembership{
+						DirName:      directory.Name,
+						DirUUIDURL:   directory.UUID,
+						GroupUUIDURL: group.UUID_URL,
+						GroupName:    group.Name,
+					},
+					UUIDURL: s.newID("appgroup"),
+				})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) updateService(w http.ResponseWriter, id string, body []byte) {
+	svc, ok := s.services[id]
+	if !ok {
+		s.writeProblem(w, http.StatusNotFound, "service not found")
+		return
+	}
+	var req client.AppService
+	if !s.decode(w, body, &req) {
+		return
+	}
+	svc.svc.Status = req.Status
+	writeJSON(w, http.StatusOK, svc.svc)
+}
+
+func (s *Server) routeRules(w http.ResponseWriter, r *http.Request, method, serviceID string, body []byte) {
+	svc, ok := s.services[serviceID]
+	if !ok {
+		s.writeProblem(w, http.StatusNotFound, "service not found")
+		return
+	}
+	switch method {
+	case http.MethodGet:
+		writeList(w, r, svc.rules)
+	case http.MethodPost:
+		var req client.AccessRuleRequest
+		if !s.decode(w, body, &req) {
+			return
+		}
+		rule := client.AccessRule{Name: req.Name, Status: req.Status, Settings: req.Settings, UUID_URL: s.newID("rule")}
+		svc.rules = append(svc.rules, rule)
+		writeJSON(w, http.StatusOK, rule)
+	default:
+		s.writeProblem(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) routeRule(w http.ResponseWriter, method, serviceID, ruleID string, body []byte) {
+	svc, ok := s.services[serviceID]
+	if !ok {
+		s.writeProblem(w, http.StatusNotFound, "service not found")
+		return
+	}
+	for i, rule := range svc.rules {
+		if rule.UUID_URL != ruleID {
+			continue
+		}
+		switch method {
+		case http.MethodPut:
+			var req client.AccessRuleRequest
+			if !s.decode(w, body, &req) {
+				return
+			}
+			svc.rules[i] = client.AccessRule{Name: req.Name, Status: req.Status, Settings: req.Settings, UUID_URL: ruleID}
+			writeJSON(w, http.StatusOK, svc.rules[i])
+		case http.MethodDelete:
+			svc.rules = append(svc.rules[:i], svc.rules[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		default:
+			s.writeProblem(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+	s.writeProblem(w, http.StatusNotFound, "rule not found")
+}
+
+func (s *Server) createCertificate(w http.ResponseWriter, body []byte) {
+	var req client.CreateSelfSignedCertRequest
+	if !s.decode(w, body, &req) {
+		return
+	}
+	if req.HostName == "" {
+		s.writeProblem(w, http.StatusBadRequest, "host_name is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.addCertificate(req.HostName, req.CertType))
+}
+
+// cipherSuites returns the tls_cipher_suite of an application that selected the suite called selected,
+// the default suite is selected when it is empty
+func (s *Server) cipherSuites(selected string) map[string]client.TLSCipherSuite {
+	suites := make(map[string]client.TLSCipherSuite, len(s.suites))
+	for _, seed := range s.suites {
+		suite := seed.suite
+		suite.Selected = seed.name == selected || (selected == "" && suite.Default)
+		suites[seed.name] = suite
+	}
+	return suites
+}
+
+func (s *Server) findAgent(id string) *client.Connector {
+	for i := range s.agents {
+		if s.agents[i].UUIDURL == id {
+			return &s.agents[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) findIDP(id string) *idp {
+	for _, i := range s.idps {
+		if i.data.UUIDURL == id {
+			return i
+		}
+	}
+	return nil
+}
+
+func (s *Server) findDirectory(idpID, dirID string) *client.DirectoryData {
+	i := s.findIDP(idpID)
+	if i == nil {
+		return nil
+	}
+	for d := range i.directories {
+		if i.directories[d].UUID == dirID {
+			return &i.directories[d]
+		}
+	}
+	return nil
+}
+
+func (s *Server) findGroup(idpID, groupID string) (*client.DirectoryData, *client.GroupData) {
+	i := s.findIDP(idpID)
+	if i == nil {
+		return nil, nil
+	}
+	for d := range i.directories {
+		for g := range i.directories[d].Groups {
+			if i.directories[d].Groups[g].UUID_URL == groupID {
+				return &i.directories[d], &i.directories[d].Groups[g]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func hasDirectory(memberships []client.AppDirectoryMembership, dirID string) bool {
+	for _, m := range memberships {
+		if m.Directory.DirectoryUUIDURL == dirID {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// writeList answers a list call with the page selected by limit and offset, limit=0 returns everything
+func writeList[T any](w http.ResponseWriter, r *http.Request, objects []T) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset < 0 || offset > len(objects) {
+		offset = len(objects)
+	}
+	end := len(objects)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	page := client.Page[T]{Objects: objects[offset:end]}
+	page.Meta.Limit = limit
+	page.Meta.Offset = offset
+	page.Meta.TotalCount = len(objects)
+	if end < len(objects) {
+		query.Set("offset", strconv.Itoa(end))
+		next := r.URL.Path + "?" + query.Encode()
+		page.Meta.Next = &next
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) decode(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		s.writeProblem(w, http.StatusBadRequest, "malformed payload: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (s *Server) writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(client.ErrorResponse{
+		Type:      "/eaa/problems/" + strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "-")),
+		Title:     http.StatusText(status),
+		Detail:    detail,
+		ProblemID: s.newID("problem"),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// generateIDP fills in the IdP side of the SAML settings of an application like EAA does
+func generateIDP(id string, doc map[string]interface{}) {
+	if saml, _ := doc["saml"].(bool); !saml {
+		return
+	}
+	settings, _ := doc["saml_settings"].([]interface{})
+	for _, item := range settings {
+		config, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		idp, _ := config["idp"].(map[string]interface{})
+		if idp == nil {
+			idp = map[string]interface{}{}
+			config["idp"] = idp
+		}
+		entityID := "https://" + id + ".login.go.akamai-access.com/saml/idp"
+		idp["entity_id"] = entityID
+		idp["slo_url"] = entityID + "/slo"
+		if cert, _ := idp["sign_cert"].(string); cert == "" {
+			idp["sign_cert"] = "-----BEGIN CERTIFICATE-----\n" + id + "\n-----END CERTIFICATE-----"
+		}
+		idp["metadata"] = fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID=%q/>`, entityID)
+	}
+}
+
+// generateOIDC fills in the endpoints and the client credentials of an OIDC application like EAA does
+func generateOIDC(id string, doc map[string]interface{}) {
+	if oidc, _ := doc["oidc"].(bool); !oidc {
+		return
+	}
+	settings, _ := doc["oidc_settings"].(map[string]interface{})
+	if settings == nil {
+		settings = map[string]interface{}{}
+		doc["oidc_settings"] = settings
+	}
+	issuer := "https://" + id + ".login.go.akamai-access.com/oidc"
+	settings["discovery_url"] = issuer + "/.well-known/openid-configuration"
+	settings["authorization_endpoint"] = issuer + "/authorize"
+	settings["token_endpoint"] = issuer + "/token"
+	settings["userinfo_endpoint"] = issuer + "/userinfo"
+	settings["jwks_uri"] = issuer + "/jwks"
+	settings["certs_uri"] = issuer + "/certs"
+	settings["end_session_endpoint"] = issuer + "/logout"
+	settings["check_session_iframe"] = issuer + "/checksession"
+	settings["openid_metadata"] = fmt.Sprintf(`{"issuer":%q}`, issuer)
+
+	clients, _ := settings["oidc_clients"].([]interface{})
+	for i, item := range clients {
+		rp, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if clientID, _ := rp["client_id"].(string); clientID == "" {
+			rp["client_id"] = fmt.Sprintf("%s-client-%d", id, i)
+		}
+		if secret, _ := rp["client_secret"].(string); secret == "" {
+			rp["client_secret"] = fmt.Sprintf("%s-secret-%d", id, i)
+		}
+		if scopes, _ := rp["scopes"].([]interface{}); len(scopes) == 0 {
+			rp["scopes"] = []interface{}{"openid", "profile", "email"}
+		}
+	}
+}
+
+// generateWSFed fills in the IdP side of the WS-Federation settings of an application like EAA does
+func generateWSFed(id string, doc map[string]interface{}) {
+	if wsfed, _ := doc["wsfed"].(bool); !wsfed {
+		return
+	}
+	settings, _ := doc["wsfed_settings"].([]interface{})
+	for _, item := range settings {
+		config, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		idp, _ := config["idp"].(map[string]interface{})
+		if idp == nil {
+			idp = map[string]interface{}{}
+			config["idp"] = idp
+		}
+		entityID := "https://" + id + ".login.go.akamai-access.com/wsfed/idp"
+		idp["entity_id"] = entityID
+		idp["metadata_url"] = entityID + "/FederationMetadata/2007-06/FederationMetadata.xml"
+		idp["sign_cert"] = "-----BEGIN CERTIFICATE-----\n" + id + "\n-----END CERTIFICATE-----"
+	}
+}
+
+func (s *Server) finishDeployment(a *app) {
+	if s.deployFails {
+		a.doc["app_deployed"] = false
+		a.doc["app_status"] = int(client.APP_STATUS_FAILED)
+		a.doc["app_operational"] = 0
+		return
+	}
+	a.doc["app_deployed"] = true
+	a.doc["app_status"] = int(client.APP_STATUS_DEPLOYED)
+	a.doc["app_operational"] = client.APP_OPERATIONAL
+}
@@ -0,0 +1,294 @@
+// Package eaatest provides an in-process, stateful fake of the EAA management API
+// (crux/v1/mgmt-pop) so the client and the provider can be tested without a tenant.
+//
+// The fake only serves requests signed with its EdgeGrid credentials, use Server.Client
+// to get an EaaClient wired to it.
+package eaatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	CONTRACT_ID   = "1-TEST"
+	CLIENT_TOKEN  = "akab-client-token-eaatest"
+	CLIENT_SECRET = "eaatest-client-secret"
+	ACCESS_TOKEN  = "akab-access-token-eaatest"
+)
+
+// Directory describes an IDP directory seeded with AddIDP
+type Directory struct {
+	Name   string
+	Groups []string
+}
+
+// Server is a fake EAA management API backed by httptest.
+// All state lives in memory and is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+	Config edgegrid.Config
+
+	mu       sync.Mutex
+	seq      int
+	apps     map[string]*app
+	appOrder []string
+	services map[string]*service
+	agents   []client.Connector
+	pops     []client.Pop
+	cats     []client.AppCate
+	idps     []*idp
+	certs    []client.CertificateResponse
+	suites   []tlsSuite
+	failures []failure
+
+	// deployPolls is how many reads a deployment stays pending for, deployFails makes it end in the failed state
+	deployPolls int
+	deployFails bool
+}
+
+type tlsSuite struct {
+	name  string
+	suite client.TLSCipherSuite
+}
+
+type app struct {
+	doc         map[string]interface{}
+	agents      []string
+	idp         *client.AppIdpMembership
+	directories []client.AppDirectoryMembership
+	groups      []client.AppGroupMembership
+	serviceID   string
+	deployments int
+	deployNote  string
+	pending     int
+}
+
+type service struct {
+	appID string
+	svc   client.AppService
+	rules []client.AccessRule
+}
+
+type idp struct {
+	data        client.IDPResponseData
+	directories []client.DirectoryData
+}
+
+type failure struct {
+	method  string
+	pattern string
+	status  int
+}
+
+// NewServer starts a fake API that is closed when the test ends
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		apps:     make(map[string]*app),
+		services: make(map[string]*service),
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	s.Config = edgegrid.Config{
+		Host:         s.Listener.Addr().String(),
+		ClientToken:  CLIENT_TOKEN,
+		ClientSecret: CLIENT_SECRET,
+		AccessToken:  ACCESS_TOKEN,
+		MaxBody:      edgegrid.MaxBodySize,
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns an EaaClient that talks to the fake.
+// Retries are disabled so injected failures surface immediately.
+func (s *Server) Client() *client.EaaClient {
+	signer := s.Config
+	return &client.EaaClient{
+		ContractID: CONTRACT_ID,
+		Client:     s.Server.Client(),
+		Signer:     &signer,
+		Host:       s.Config.Host,
+		Logger:     hclog.NewNullLogger(),
+	}
+}
+
+// FailNext makes the next request matching method and pattern answer with status.
+// pattern is matched with path.Match against the path below crux/v1/mgmt-pop, e.g. "apps/*/deploy".
+func (s *Server) FailNext(method, pattern string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, pattern: pattern, status: status})
+}
+
+// takeFailure pops the first injected failure matching the request
+func (s *Server) takeFailure(method, resource string) (int, bool) {
+	for i, f := range s.failures {
+		if f.method != method {
+			continue
+		}
+		if ok, _ := path.Match(f.pattern, resource); ok {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return f.status, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) newID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%04d", prefix, s.seq)
+}
+
+// AddAgent seeds a connector and returns its uuid_url
+func (s *Server) AddAgent(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID("agent")
+	s.agents = append(s.agents, client.Connector{Name: name, UUIDURL: id, State: 1, Reach: 1})
+	return id
+}
+
+// AddPop seeds a pop and returns its uuid_url
+func (s *Server) AddPop(name, region string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID("pop")
+	s.pops = append(s.pops, client.Pop{Name: name, Region: region, UUIDURL: id})
+	return id
+}
+
+// AddAppCategory seeds an application category and returns its uuid_url
+func (s *Server) AddAppCategory(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID("cat")
+	s.cats = append(s.cats, client.AppCate{Name: name, UUIDURL: id})
+	return id
+}
+
+// AddIDP seeds an identity provider with its directories and groups and returns its uuid_url
+func (s *Server) AddIDP(name string, directories ...Directory) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := &idp{data: client.IDPResponseData{Name: name, UUIDURL: s.newID("idp")}}
+	for _, dir := range directories {
+		d := client.DirectoryData{Name: dir.Name, UUID: s.newID("dir")}
+		for _, group := range dir.Groups {
+			d.Groups = append(d.Groups, client.GroupData{Name: group, UUID_URL: s.newID("group")})
+		}
+		i.directories = append(i.directories, d)
+	}
+	s.idps = append(s.idps, i)
+	return i.data.UUIDURL
+}
+
+// AddTLSCipherSuite seeds a cipher suite applications can select, the first one is the default
+func (s *Server) AddTLSCipherSuite(name, protocols string, weak bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suites = append(s.suites, tlsSuite{
+		name: name,
+		suite: client.TLSCipherSuite{
+			Default:      len(s.suites) == 0,
+			SSLCipher:    "ECDHE-RSA-AES256-GCM-SHA384:" + name,
+			SSLProtocols: protocols,
+			WeakCipher:   weak,
+		},
+	})
+}
+
+// AddCertificate seeds a certificate and returns its uuid_url
+func (s *Server) AddCertificate(name string, certType int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addCertificate(name, certType).UUIDURL
+}
+
+func (s *Server) addCertificate(name string, certType int) client.CertificateResponse {
+	cert := client.CertificateResponse{
+		Name:     name,
+		HostName: name,
+		CN:       name,
+		CertType: certType,
+		Cert:     fmt.Sprintf("-----BEGIN CERTIFICATE-----\n%s\n-----END CERTIFICATE-----\n", name),
+		UUIDURL:  s.newID("cert"),
+	}
+	s.certs = append(s.certs, cert)
+	return cert
+}
+
+// App returns the application stored under id as the API would return it
+func (s *Server) App(id string) (client.ApplicationDataModel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var model client.ApplicationDataModel
+	a, ok := s.apps[id]
+	if !ok {
+		return model, false
+	}
+	data, _ := json.Marshal(a.doc)
+	_ = json.Unmarshal(data, &model)
+	return model, true
+}
+
+// AppIDs returns the uuid_url of every stored application in creation order
+func (s *Server) AppIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.appOrder...)
+}
+
+// Deployments returns how many times the application was deployed
+func (s *Server) Deployments(id string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.apps[id]; ok {
+		return a.deployments
+	}
+	return 0
+}
+
+// DeployNote returns the note of the last deployment of the application
+func (s *Server) DeployNote(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.apps[id]; ok {
+		return a.deployNote
+	}
+	return ""
+}
+
+// SlowDeployments makes the next deployments report pending for polls reads of the application
+func (s *Server) SlowDeployments(polls int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deployPolls = polls
+}
+
+// FailDeployments makes the next deployments end in the failed state
+func (s *Server) FailDeployments() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deployFails = true
+}
+
+// AccessRules returns the access control rules of the application
+func (s *Server) AccessRules(id string) []client.AccessRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.apps[id]
+	if !ok {
+		return nil
+	}
+	return append([]client.AccessRule(nil), s.services[a.serviceID].rules...)
+}
@@ -0,0 +1,71 @@
+package eaatest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
+)
+
+func TestRejectsUnsignedRequests(t *testing.T) {
+	srv := NewServer(t)
+
+	resp, err := srv.Server.Client().Get(srv.URL + "/" + client.AGENTS_URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	ec := srv.Client()
+	wrong := srv.Config
+	wrong.ClientSecret = "not-the-secret"
+	ec.Signer = &wrong
+	_, err = client.GetAgents(context.Background(), ec)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("err = %v, want a 401 APIError", err)
+	}
+}
+
+func TestListsArePaginated(t *testing.T) {
+	srv := NewServer(t)
+	for i := 0; i < 5; i++ {
+		srv.AddAgent(fmt.Sprintf("connector-%d", i))
+	}
+
+	ec := srv.Client()
+	ec.PageSize = 2
+	agents, err := client.GetAgents(context.Background(), ec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(agents) != 5 {
+		t.Fatalf("got %d agents, want 5", len(agents))
+	}
+	for i, agent := range agents {
+		if agent.Name != fmt.Sprintf("connector-%d", i) {
+			t.Errorf("agents[%d] = %s", i, agent.Name)
+		}
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv := NewServer(t)
+	srv.AddAppCategory("Finance")
+	srv.FailNext(http.MethodGet, "appcategories", http.StatusServiceUnavailable)
+
+	ec := srv.Client()
+	if _, err := client.GetAppCategories(context.Background(), ec); !errors.Is(err, client.ErrAppCategoriesGet) {
+		t.Errorf("err = %v, want %v", err, client.ErrAppCategoriesGet)
+	}
+	cats, err := client.GetAppCategories(context.Background(), ec)
+	if err != nil || len(cats) != 1 {
+		t.Errorf("got %v, %v after the injected failure", cats, err)
+	}
+}
@@ -1,117 +1,16 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"strings"
 
+	"git.source.akamai.com/terraform-provider-eaa/pkg/client"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v6/pkg/edgegrid"
+	"github.com/hashicorp/go-hclog"
 )
 
-var (
-	ErrInvalidArgument = errors.New("invalid arguments provided")
-	ErrMarshaling      = errors.New("marshaling input")
-	ErrUnmarshaling    = errors.New("unmarshaling output")
-)
-
-const (
-	MGMT_POP_URL = "crux/v1/mgmt-pop"
-	APPS_URL     = "crux/v1/mgmt-pop/apps"
-)
-
-const (
-	APP_TYPE_ENTERPRISE_HOSTED = 1
-	APP_TYPE_SAAS              = 2
-	APP_TYPE_BOOKMARK          = 3
-	APP_TYPE_TUNNEL            = 4
-	APP_TYPE_ETP               = 5
-)
-
-type EaaClient struct {
-	ContractID       string
-	AccountSwitchKey string
-	Client           *http.Client
-	Signer           edgegrid.Signer
-	Host             string
-}
-
-type AppsResponse struct {
-	Meta struct {
-		TotalCount int `json:"total_count"`
-	} `json:"meta"`
-	Applications []Application `json:"objects"`
-}
-
-type Server struct {
-	OriginHost     string `json:"origin_host"`
-	OrigTLS        bool   `json:"orig_tls"`
-	OriginPort     int    `json:"origin_port"`
-	OriginProtocol string `json:"origin_protocol"`
-}
-
-type TunnelInternalHost struct {
-	Host      string `json:"host"`
-	PortRange string `json:"port_range"`
-	ProtoType int    `json:"proto_type"`
-}
-
-type Application struct {
-	Name          string  `json:"name"`
-	Description   *string `json:"description"`
-	AppProfile    int     `json:"app_profile"`
-	AppType       int     `json:"app_type"`
-	ClientAppMode int     `json:"client_app_mode"`
-
-	Host        *string `json:"host"`
-	BookmarkURL string  `json:"bookmark_url"`
-	AppLogo     *string `json:"app_logo"`
-
-	OrigTLS             string               `json:"orig_tls"`
-	OriginHost          *string              `json:"origin_host"`
-	OriginPort          int                  `json:"origin_port"`
-	TunnelInternalHosts []TunnelInternalHost `json:"tunnel_internal_hosts"`
-	Servers             []Server             `json:"servers"`
-
-	POP       string `json:"pop"`
-	POPName   string `json:"popName"`
-	POPRegion string `json:"popRegion"`
-
-	AuthType    int     `json:"auth_type"`
-	Cert        *string `json:"cert"`
-	AuthEnabled string  `json:"auth_enabled"`
-	SSLCACert   string  `json:"ssl_ca_cert"`
-
-	AppDeployed    bool    `json:"app_deployed"`
-	AppOperational int     `json:"app_operational"`
-	AppStatus      int     `json:"app_status"`
-	CName          *string `json:"cname"`
-	Status         int     `json:"status"`
-
-	AdvancedSettings AdvancedSettings `json:"advanced_settings"`
-
-	UUIDURL string `json:"uuid_url"`
-}
-
-type AdvancedSettings struct {
-	IsSSLVerificationEnabled  string  `json:"is_ssl_verification_enabled,omitempty"`
-	IgnoreCnameResolution     string  `json:"ignore_cname_resolution,omitempty"`
-	EdgeAuthenticationEnabled string  `json:"edge_authentication_enabled,omitempty"`
-	G2OEnabled                string  `json:"g2o_enabled,omitempty"`
-	G2ONonce                  *string `json:"g2o_nonce,omitempty"`
-	G2OKey                    *string `json:"g2o_key,omitempty"`
-	XWappReadTimeout          string  `json:"x_wapp_read_timeout,omitempty"`
-	InternalHostname          *string `json:"internal_hostname,omitempty"`
-	InternalHostPort          string  `json:"internal_host_port,omitempty"`
-	WildcardInternalHostname  string  `json:"wildcard_internal_hostname,omitempty"`
-	IPAccessAllow             string  `json:"ip_access_allow,omitempty"`
-}
-
 func main() {
 	var contractID string
 	var accountSwitch string
@@ -141,12 +40,21 @@ func main() {
 		fmt.Println("EdgeRc error")
 	}
 
-	eaaClient := &EaaClient{
-		Client:           http.DefaultClient,
+	// same transport as the provider with its defaults: request timeout, TLS 1.2 and the HTTPS_PROXY variables
+	httpClient, err := client.NewHTTPClient(client.TransportOptions{})
+	if err != nil {
+		fmt.Println("Error building the HTTP client:", err)
+		return
+	}
+
+	eaaClient := &client.EaaClient{
+		Client:           httpClient,
 		ContractID:       contractID,
 		Signer:           edgerc,
 		AccountSwitchKey: accountSwitch,
 		Host:             edgerc.Host,
+		Logger:           hclog.New(&hclog.LoggerOptions{Name: "import-config", Level: hclog.Warn}),
+		MaxRetries:       client.DEFAULT_MAX_RETRIES,
 	}
 	err = GenerateConfiguration(eaaClient, appNames)
 	if err != nil {
@@ -155,15 +63,9 @@ func main() {
 
 }
 
-func GenerateConfiguration(ec *EaaClient, appNames string) error {
-	apiURL := fmt.Sprintf("https://%s/%s", ec.Host, APPS_URL)
-	appsResponse := AppsResponse{}
-
-	getResp, err := ec.SendAPIRequest(apiURL, "GET", nil, &appsResponse, false)
+func GenerateConfiguration(ec *client.EaaClient, appNames string) error {
+	apps, err := client.GetApplications(context.Background(), ec)
 	if err != nil {
-		return err
-	}
-	if !(getResp.StatusCode >= http.StatusOK && getResp.StatusCode < http.StatusMultipleChoices) {
 		fmt.Println("get apps failed")
 		return err
 	}
@@ -200,8 +102,9 @@ func GenerateConfiguration(ec *EaaClient, appNames string) error {
 	}
 	appList := strings.Split(strings.ToLower(appNames), ",")
 	for _, pattern := range appList {
-		for _, app := range appsResponse.Applications {
-			if app.Name == "" || app.UUIDURL == "" || !(app.AppType == APP_TYPE_ENTERPRISE_HOSTED || app.AppType == APP_TYPE_TUNNEL) {
+		for _, app := range apps {
+			appType := client.ClientAppTypeInt(app.AppType)
+			if app.Name == "" || app.UUIDURL == "" || !(appType == client.APP_TYPE_ENTERPRISE_HOSTED || appType == client.APP_TYPE_TUNNEL || appType == client.APP_TYPE_SAAS) {
 				continue
 			}
 
@@ -246,55 +149,3 @@ func matchesPattern(s, pattern string) bool {
 	}
 	return s == pattern
 }
-
-// Exec will sign and execute the request using the client edgegrid.Config
-func (ec *EaaClient) SendAPIRequest(apiURL string, method string, in interface{}, out interface{}, global bool) (*http.Response, error) {
-	if !global {
-		queryParams := url.Values{}
-		if ec.ContractID != "" {
-			queryParams.Set("contractId", ec.ContractID)
-		}
-		if ec.AccountSwitchKey != "" {
-			queryParams.Set("accountSwitchKey", ec.AccountSwitchKey)
-		}
-		if method == http.MethodGet {
-			queryParams.Set("expand", "true")
-			queryParams.Set("limit", "0")
-		}
-		apiURL = fmt.Sprintf("%s?%s", apiURL, queryParams.Encode())
-	}
-
-	r, _ := http.NewRequest(method, apiURL, nil)
-	r.Header.Set("Content-Type", "application/json")
-
-	r.URL.RawQuery = r.URL.Query().Encode()
-	if in != nil {
-		data, err := json.Marshal(in)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %s", ErrMarshaling, err)
-		}
-
-		r.Body = io.NopCloser(bytes.NewBuffer(data))
-		r.ContentLength = int64(len(data))
-	}
-	ec.Signer.SignRequest(r)
-
-	resp, err := ec.Client.Do(r)
-	if err != nil {
-		return nil, err
-	}
-	if out != nil &&
-		resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices &&
-		resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusResetContent {
-		data, err := io.ReadAll(resp.Body)
-		resp.Body = io.NopCloser(bytes.NewBuffer(data))
-		if err != nil {
-			return nil, err
-		}
-		if err := json.Unmarshal(data, out); err != nil {
-			return nil, fmt.Errorf("%w: %s", ErrUnmarshaling, err)
-		}
-	}
-
-	return resp, nil
-}